@@ -0,0 +1,88 @@
+package calendar
+
+import (
+	"io"
+	"strings"
+	"time"
+)
+
+func init() {
+	layouts["agenda"] = writeAgendaCalendar
+}
+
+// agendaCheckbox is the markdown task list marker used for notes when Checkboxes is set
+const agendaCheckbox = "- [ ] "
+
+// agendaNotes creates the notes cell of a day from its annotations, one per line
+func agendaNotes(annotations []Annotation, checkboxes bool) string {
+	if len(annotations) == 0 {
+		if checkboxes {
+			return strings.TrimSpace(agendaCheckbox)
+		}
+		return ""
+	}
+
+	notes := make([]string, 0, len(annotations))
+	for _, a := range annotations {
+		note := formatAnnotation(a)
+		if checkboxes {
+			note = agendaCheckbox + note
+		}
+		notes = append(notes, note)
+	}
+	return strings.Join(notes, "<br>")
+}
+
+// generateAgendaMonth creates the agenda table for a single month, with one row per displayed day
+func generateAgendaMonth(firstOfMonth time.Time, options Options, annotators []Annotator) string {
+	var sb strings.Builder
+
+	sb.WriteString(generateCalendarHeader(firstOfMonth.Year(), firstOfMonth.Month()))
+
+	columnHeaders := []string{"Date", "Weekday"}
+	if options.ShowCalendarWeek {
+		columnHeaders = append(columnHeaders, "CW")
+	}
+	columnHeaders = append(columnHeaders, "Notes")
+
+	var rows [][]string
+	for date := firstOfMonth; date.Month() == firstOfMonth.Month(); date = date.AddDate(0, 0, 1) {
+		if !options.ShowWeekends && isWeekend(date.Weekday()) {
+			continue
+		}
+
+		weekday := date.Weekday().String()
+		if options.UseShortDayNames {
+			weekday = weekday[:3]
+		}
+		row := []string{options.dayLabel(date, date.Format(time.DateOnly)), weekday}
+		if options.ShowCalendarWeek {
+			row = append(row, "_"+options.weekLabel(date)+"_")
+		}
+		row = append(row, agendaNotes(annotate(date, annotators), options.Checkboxes))
+		rows = append(rows, row)
+	}
+
+	sb.WriteString(generateTable(columnHeaders, rows, options.Justify))
+
+	return sb.String()
+}
+
+// writeAgendaCalendar streams a daily log with one table per month and one row per day,
+// with the notes pre-populated from the annotations of the day
+func writeAgendaCalendar(w io.Writer, options Options) error {
+	annotators := sortedAnnotators(options.Annotators)
+	first := true
+
+	return forEachMonth(options, func(firstOfMonth time.Time) error {
+		output := generateAgendaMonth(firstOfMonth, options, annotators)
+		if !first {
+			// Add a blank line between months
+			output = "\n" + output
+		}
+		first = false
+
+		_, err := io.WriteString(w, output)
+		return err
+	})
+}
@@ -0,0 +1,60 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAgendaNotes(t *testing.T) {
+	annotations := []Annotation{{Text: "Standup"}, {Text: "Release", Link: "r.md"}}
+
+	tests := []struct {
+		name        string
+		annotations []Annotation
+		checkboxes  bool
+		expected    string
+	}{
+		{"Empty", nil, false, ""},
+		{"Empty with checkbox", nil, true, "- [ ]"},
+		{"Annotations", annotations, false, "Standup<br>[Release](r.md)"},
+		{"Annotations with checkboxes", annotations, true, "- [ ] Standup<br>- [ ] [Release](r.md)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.expected, agendaNotes(tt.annotations, tt.checkboxes)); diff != "" {
+				t.Errorf("agendaNotes() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateAgendaMonth(t *testing.T) {
+	options := NewOptions()
+	options.UseShortDayNames = true
+	options.ShowWeekends = false
+	annotators := []Annotator{Holidays(map[string]string{"2025-02-03": "Founders Day"})}
+
+	output := generateAgendaMonth(time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC), options, annotators)
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+
+	expected := []string{
+		"# February 2025",
+		"",
+		"| Date       | Weekday | CW  | Notes        |",
+		"| :--------- | :------ | :-- | :----------- |",
+		"| 2025-02-03 | Mon     | _6_ | Founders Day |",
+		"| 2025-02-04 | Tue     | _6_ |              |",
+	}
+	if diff := cmp.Diff(expected, lines[:6]); diff != "" {
+		t.Errorf("generateAgendaMonth() mismatch (-want +got):\n%s", diff)
+	}
+
+	// February 2025 has 20 weekdays
+	if rows := len(lines) - 4; rows != 20 {
+		t.Errorf("generateAgendaMonth() wrote %d day rows, want 20", rows)
+	}
+}
@@ -0,0 +1,77 @@
+package calendar
+
+import (
+	"sort"
+	"time"
+)
+
+// Annotation is a piece of custom data attached to a single day, such as a deploy freeze or an on-call shift
+type Annotation struct {
+	Text    string
+	Link    string // optional link target for Text
+	Holiday bool   // marks the day as a holiday in layouts that highlight holidays
+}
+
+// Annotator returns the annotations for a single day. It may return nil for days without annotations.
+type Annotator func(date time.Time) []Annotation
+
+// AnnotatorEntry is an annotator registered on Options together with its priority
+type AnnotatorEntry struct {
+	Priority int
+	Annotate Annotator
+}
+
+// AddAnnotator registers an annotator that runs for every day of the month before rendering.
+// Annotators run in ascending priority order and annotators with equal priority run in the
+// order they were added, so their annotations appear on the day in that order.
+func (o *Options) AddAnnotator(priority int, annotator Annotator) {
+	o.Annotators = append(o.Annotators, AnnotatorEntry{Priority: priority, Annotate: annotator})
+}
+
+// sortedAnnotators returns the registered annotators in the order they should run
+func sortedAnnotators(entries []AnnotatorEntry) []Annotator {
+	sorted := append([]AnnotatorEntry(nil), entries...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Priority < sorted[j].Priority
+	})
+
+	annotators := make([]Annotator, 0, len(sorted))
+	for _, entry := range sorted {
+		if entry.Annotate != nil {
+			annotators = append(annotators, entry.Annotate)
+		}
+	}
+	return annotators
+}
+
+// annotate runs every annotator for the date and collects their annotations in order
+func annotate(date time.Time, annotators []Annotator) []Annotation {
+	var annotations []Annotation
+	for _, annotator := range annotators {
+		annotations = append(annotations, annotator(date)...)
+	}
+	return annotations
+}
+
+// Holidays returns an annotator that marks the given dates as holidays. Dates are keyed by their
+// YYYY-MM-DD form and map to the holiday name, which may be empty.
+func Holidays(holidays map[string]string) Annotator {
+	return func(date time.Time) []Annotation {
+		name, ok := holidays[date.Format(time.DateOnly)]
+		if !ok {
+			return nil
+		}
+		if name == "" {
+			name = "Holiday"
+		}
+		return []Annotation{{Text: name, Holiday: true}}
+	}
+}
+
+// Events returns an annotator that adds annotations to their dates. Annotations are keyed by the
+// YYYY-MM-DD form of their date, in the order they appear on the day.
+func Events(events map[string][]Annotation) Annotator {
+	return func(date time.Time) []Annotation {
+		return events[date.Format(time.DateOnly)]
+	}
+}
@@ -0,0 +1,63 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAddAnnotatorOrdering(t *testing.T) {
+	label := func(text string) Annotator {
+		return func(date time.Time) []Annotation {
+			return []Annotation{{Text: text}}
+		}
+	}
+
+	options := NewOptions()
+	options.AddAnnotator(10, label("late"))
+	options.AddAnnotator(0, label("first"))
+	options.AddAnnotator(0, label("second"))
+	options.AddAnnotator(-5, label("early"))
+	options.AddAnnotator(0, nil)
+
+	annotations := annotate(time.Date(2025, time.March, 14, 0, 0, 0, 0, time.UTC), sortedAnnotators(options.Annotators))
+
+	expected := []Annotation{{Text: "early"}, {Text: "first"}, {Text: "second"}, {Text: "late"}}
+	if diff := cmp.Diff(expected, annotations); diff != "" {
+		t.Errorf("annotate() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBuildMonthAnnotations(t *testing.T) {
+	var seen []time.Time
+	options := NewOptions()
+	options.Year = 2025
+	options.Month = intPtr(3)
+	options.AddAnnotator(0, func(date time.Time) []Annotation {
+		seen = append(seen, date)
+		if date.Day() == 14 {
+			return []Annotation{{Text: "Deploy freeze"}}
+		}
+		return nil
+	})
+
+	month := BuildMonth(options)
+
+	if len(seen) != 31 {
+		t.Errorf("BuildMonth() ran annotators for %d days, want 31", len(seen))
+	}
+
+	for _, week := range month.Weeks {
+		for _, day := range week.Days {
+			if !day.InMonth && day.Annotations != nil {
+				t.Errorf("BuildMonth() annotated padding day %v", day.Date)
+			}
+			if day.InMonth && day.Date.Day() == 14 {
+				if diff := cmp.Diff([]Annotation{{Text: "Deploy freeze"}}, day.Annotations); diff != "" {
+					t.Errorf("BuildMonth() annotations mismatch (-want +got):\n%s", diff)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package calendar
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+func init() {
+	layouts["continuous"] = writeContinuousCalendar
+}
+
+// isFirstDisplayedDay reports whether the date is the first day of its month shown in the calendar,
+// which is later than the 1st when the month starts on a hidden weekend
+func isFirstDisplayedDay(date time.Time, showWeekends bool) bool {
+	for d := date.AddDate(0, 0, -1); d.Month() == date.Month(); d = d.AddDate(0, 0, -1) {
+		if showWeekends || !isWeekend(d.Weekday()) {
+			return false
+		}
+	}
+	return true
+}
+
+// continuousDayCell creates the cell for a day of the continuous layout, flagging the first displayed day of
+// each month with the month name and leaving days outside the selected range blank
+func continuousDayCell(date time.Time, start time.Time, end time.Time, annotators []Annotator, options Options) string {
+	if date.Before(start) || date.After(end) {
+		return ""
+	}
+
+	if isFirstDisplayedDay(date, options.ShowWeekends) {
+		return "**" + options.dayLabel(date, fmt.Sprintf("%s %d", date.Month().String()[:3], date.Day())) + "**" +
+			formatAnnotations(annotate(date, annotators))
+	}
+	return options.dayLabel(date, fmt.Sprintf("%d", date.Day())) + formatAnnotations(annotate(date, annotators))
+}
+
+// continuousRowCells creates the cells of the week starting on weekStart
+func continuousRowCells(weekStart time.Time, weekDays []time.Weekday, start time.Time, end time.Time, annotators []Annotator,
+	options Options) []string {
+	var cells []string
+	if options.ShowCalendarWeek {
+		cells = append(cells, "_"+options.weekLabel(weekStart)+"_")
+	}
+	for _, wd := range weekDays {
+		delta := (int(wd) - int(options.FirstDayOfWeek) + 7) % 7
+		cells = append(cells, continuousDayCell(weekStart.AddDate(0, 0, delta), start, end, annotators, options))
+	}
+	if options.ShowComments {
+		cells = append(cells, "")
+	}
+	return cells
+}
+
+// writeContinuousCalendar streams a single table of weeks covering the whole selected range, so that weeks
+// spanning two months are not split. The rows are rendered twice, first to size the columns to their widest cell
+// and then to write them, so that the range is never held in memory.
+func writeContinuousCalendar(w io.Writer, options Options) error {
+	start, end := options.DateRange()
+	annotators := sortedAnnotators(options.Annotators)
+
+	dayShortNames, dayFullNames := getWeekdayNames(options.FirstDayOfWeek, options.ShowWeekends)
+	weekDays := convertToWeekdays(dayShortNames)
+	columnHeaders, columnWidths := prepareColumnHeaders(dayShortNames, dayFullNames, options.UseShortDayNames, options.ShowCalendarWeek,
+		options.ShowComments, options.Justify)
+
+	_, _, weekStart := calculateMonthBoundaries(start.Year(), start.Month(), options.FirstDayOfWeek)
+	for cur := weekStart; !cur.After(end); cur = cur.AddDate(0, 0, 7) {
+		columnWidths = fitColumnWidths(columnWidths, continuousRowCells(cur, weekDays, start, end, annotators, options))
+	}
+
+	header := generateRangeHeader(Month{Year: start.Year(), Month: start.Month()}, Month{Year: end.Year(), Month: end.Month()})
+	if _, err := io.WriteString(w, header+generateTableHeader(columnHeaders, columnWidths, options.Justify)); err != nil {
+		return err
+	}
+
+	for cur := weekStart; !cur.After(end); cur = cur.AddDate(0, 0, 7) {
+		if _, err := io.WriteString(w, generateTableRow(continuousRowCells(cur, weekDays, start, end, annotators, options), columnWidths)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
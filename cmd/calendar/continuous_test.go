@@ -0,0 +1,118 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIsFirstDisplayedDay(t *testing.T) {
+	tests := []struct {
+		name         string
+		date         time.Time
+		showWeekends bool
+		expected     bool
+	}{
+		{"First of month", time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC), true, true},
+		{"Second of month", time.Date(2025, time.March, 2, 0, 0, 0, 0, time.UTC), true, false},
+		{"Monday after hidden weekend start", time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC), false, true},
+		{"Monday after shown weekend start", time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC), true, false},
+		{"Tuesday after hidden weekend start", time.Date(2025, time.March, 4, 0, 0, 0, 0, time.UTC), false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := isFirstDisplayedDay(tt.date, tt.showWeekends); actual != tt.expected {
+				t.Errorf("isFirstDisplayedDay(%v, %v) = %v, want %v", tt.date, tt.showWeekends, actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWriteContinuousCalendar(t *testing.T) {
+	options := NewOptions()
+	options.Year = 2025
+	options.Month = intPtr(1)
+	options.EndYear = intPtr(2025)
+	options.EndMonth = intPtr(2)
+	options.Layout = "continuous"
+	options.UseShortDayNames = true
+	options.ShowWeekends = false
+	options.ShowComments = false
+
+	output, err := PrintCalendar(options)
+	if err != nil {
+		t.Fatalf("PrintCalendar() returned error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+	expectedStart := []string{
+		"# January – February 2025",
+		"",
+		"| CW   | Mon       | Tue | Wed       | Thu | Fri |",
+		"| :--- | :-------- | :-- | :-------- | :-- | :-- |",
+		"| _1_  |           |     | **Jan 1** | 2   | 3   |",
+	}
+	if diff := cmp.Diff(expectedStart, lines[:5]); diff != "" {
+		t.Errorf("PrintCalendar() start mismatch (-want +got):\n%s", diff)
+	}
+
+	// The week of Jan 27 reaches into February, but its February days fall on the hidden weekend, so the row ends
+	// on Jan 31 and February is flagged on its first displayed day, Monday Feb 3, in the next row
+	expectedCrossing := "| _5_  | 27        | 28  | 29        | 30  | 31  |"
+	expectedFebruary := "| _6_  | **Feb 3** | 4   | 5         | 6   | 7   |"
+	if lines[8] != expectedCrossing || lines[9] != expectedFebruary {
+		t.Errorf("PrintCalendar() month boundary rows = %q, %q", lines[8], lines[9])
+	}
+
+	seen := map[string]bool{}
+	for _, line := range lines[4:] {
+		cw := strings.Fields(line)[1]
+		if seen[cw] {
+			t.Errorf("PrintCalendar() repeats week %s", cw)
+		}
+		seen[cw] = true
+	}
+	if len(seen) != 9 {
+		t.Errorf("PrintCalendar() wrote %d weeks, want 9", len(seen))
+	}
+}
+
+func TestWriteContinuousCalendarDayLinks(t *testing.T) {
+	options := NewOptions()
+	options.Year = 2025
+	options.Month = intPtr(1)
+	options.EndYear = intPtr(2025)
+	options.EndMonth = intPtr(3)
+	options.Layout = "continuous"
+	options.DayLabel = func(date time.Time, text string) string {
+		return "[" + text + "](journal/" + date.Format(time.DateOnly) + ".md)"
+	}
+
+	output, err := PrintCalendar(options)
+	if err != nil {
+		t.Fatalf("PrintCalendar() returned error: %v", err)
+	}
+
+	// Every row has its cell borders at the same columns as the header, however long the links of its days
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")[2:]
+	borders := func(line string) []int {
+		var columns []int
+		for i, r := range []rune(line) {
+			if r == '|' {
+				columns = append(columns, i)
+			}
+		}
+		return columns
+	}
+	for _, line := range lines[1:] {
+		if diff := cmp.Diff(borders(lines[0]), borders(line)); diff != "" {
+			t.Errorf("PrintCalendar() row %q is not aligned with the header (-want +got):\n%s", line, diff)
+		}
+	}
+	if !strings.Contains(output, "| **[Mar 1](journal/2025-03-01.md)** |") {
+		t.Errorf("PrintCalendar() did not link the month start labels:\n%s", output)
+	}
+}
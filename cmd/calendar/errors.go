@@ -0,0 +1,156 @@
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"github.com/andre-a-alves/mdcal/cmd/utils"
+	"strings"
+	"time"
+)
+
+// Errors reported by Options.Validate, Heatmap.Validate and the writers of other calendars, wrapped in a
+// *ValidationError
+var (
+	ErrInvalidYear          = errors.New("year must be between 1 and 9999")
+	ErrInvalidMonth         = errors.New("month must be between 1 and 12")
+	ErrIncompleteRange      = errors.New("end year and end month must be set together")
+	ErrRangeReversed        = errors.New("end date cannot be before start date")
+	ErrInvalidWeekday       = errors.New("invalid weekday")
+	ErrInvalidJustify       = errors.New("invalid justification")
+	ErrUnknownFormat        = errors.New("unknown format")
+	ErrUnknownLayout        = errors.New("unknown layout")
+	ErrUnsupportedLayout    = errors.New("layout is only available in markdown format")
+	ErrInvalidColumns       = errors.New("columns must be at least 1")
+	ErrUnsupportedColumns   = errors.New("months side by side are only available in the grid layout with a multi-month renderer")
+	ErrInvalidHours         = errors.New("hours must be a range within the day, such as 08:00-18:00")
+	ErrInvalidSlot          = errors.New("time slot must be longer than zero")
+	ErrNoHabits             = errors.New("the habits layout needs at least one habit")
+	ErrUnsupportedTranspose = errors.New("transpose is only available in the grid layout with a single column")
+	ErrUnknownGlyphs        = errors.New("unknown heatmap glyphs")
+	ErrInvalidPeriod        = errors.New("heatmap period must be month or year")
+	ErrInvalidThresholds    = errors.New("thresholds must be increasing positive values, one fewer than the levels of the glyphs at most")
+	ErrUnsupportedHeatmap   = errors.New("not available in heatmaps, which have a table per month or year of their own")
+	ErrUnsupportedTeam      = errors.New("not available in team calendars, which only show the availability of the members")
+)
+
+// ErrNoMembers is reported by WriteTeam for a team without members
+var ErrNoMembers = errors.New("the team needs at least one member")
+
+// ValidationError describes an invalid option value
+type ValidationError struct {
+	Field      string // name of the invalid Options field
+	Value      string // the rejected value
+	Suggestion string // closest valid value, if any
+	Err        error  // one of the Err* sentinel errors
+}
+
+func (e *ValidationError) Error() string {
+	msg := fmt.Sprintf("%s %q: %v", e.Field, e.Value, e.Err)
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf(" (did you mean %q?)", e.Suggestion)
+	}
+	return msg
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// justifyValues lists the accepted cell justifications
+var justifyValues = []string{"left", "center", "right"}
+
+// ParseWeekday converts a weekday name such as "monday" or "mon" to time.Weekday.
+// Unknown names are reported as ErrInvalidWeekday with the closest valid spelling as a suggestion.
+func ParseWeekday(day string) (time.Weekday, error) {
+	if weekday, ok := utils.LookupWeekday(day); ok {
+		return weekday, nil
+	}
+
+	return time.Monday, &ValidationError{
+		Field:      "FirstDayOfWeek",
+		Value:      day,
+		Suggestion: utils.Suggest(day, utils.WeekdaySpellings()),
+		Err:        ErrInvalidWeekday,
+	}
+}
+
+// validateJustify checks the cell justification against the accepted values
+func validateJustify(justify string) error {
+	for _, j := range justifyValues {
+		if strings.ToLower(justify) == j {
+			return nil
+		}
+	}
+
+	return &ValidationError{
+		Field:      "Justify",
+		Value:      justify,
+		Suggestion: utils.Suggest(justify, justifyValues),
+		Err:        ErrInvalidJustify,
+	}
+}
+
+// validateFormat checks that a renderer is registered for the format
+func validateFormat(format string) error {
+	if _, ok := LookupRenderer(format); ok {
+		return nil
+	}
+
+	return &ValidationError{
+		Field:      "Format",
+		Value:      format,
+		Suggestion: utils.Suggest(format, RendererNames()),
+		Err:        ErrUnknownFormat,
+	}
+}
+
+// validateLayout checks that the layout exists and that it supports the format
+func validateLayout(layout string, format string) error {
+	if layout != GridLayout && layouts[layout] == nil {
+		return &ValidationError{
+			Field:      "Layout",
+			Value:      layout,
+			Suggestion: utils.Suggest(layout, LayoutNames()),
+			Err:        ErrUnknownLayout,
+		}
+	}
+
+	if layout != GridLayout && format != "markdown" {
+		return &ValidationError{Field: "Layout", Value: layout, Err: ErrUnsupportedLayout}
+	}
+
+	return nil
+}
+
+// validateOwnLayout rejects the layout, columns and transpose options in calendars that lay out their own tables,
+// such as heatmaps, reporting each one set as err
+func validateOwnLayout(o Options, err error) error {
+	var errs []error
+	if o.Layout != GridLayout {
+		errs = append(errs, &ValidationError{Field: "Layout", Value: o.Layout, Err: err})
+	}
+	if o.Columns != 1 {
+		errs = append(errs, &ValidationError{Field: "Columns", Value: fmt.Sprint(o.Columns), Err: err})
+	}
+	if o.Transpose {
+		errs = append(errs, &ValidationError{Field: "Transpose", Value: fmt.Sprint(o.Transpose), Err: err})
+	}
+	return errors.Join(errs...)
+}
+
+// validateColumns checks that months can be placed side by side with the layout and format
+func validateColumns(columns int, layout string, format string) error {
+	if columns < 1 {
+		return &ValidationError{Field: "Columns", Value: fmt.Sprint(columns), Err: ErrInvalidColumns}
+	}
+	if columns == 1 {
+		return nil
+	}
+
+	renderer, _ := LookupRenderer(format)
+	if _, ok := renderer.(MultiMonthRenderer); !ok || layout != GridLayout {
+		return &ValidationError{Field: "Columns", Value: fmt.Sprint(columns), Err: ErrUnsupportedColumns}
+	}
+
+	return nil
+}
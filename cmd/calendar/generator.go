@@ -2,16 +2,11 @@ package calendar
 
 import (
 	"fmt"
-	"github.com/andre-a-alves/mdcal/cmd/utils"
+	"io"
 	"strings"
 	"time"
 )
 
-// generateCalendarHeader creates the header for the calendar with month and year
-func generateCalendarHeader(year int, month time.Month) string {
-	return fmt.Sprintf("# %s %d\n\n", month.String(), year)
-}
-
 // getWeekdayNames returns the short and full names of weekdays based on the first day of the week
 func getWeekdayNames(firstDayOfWeek time.Weekday, showWeekends bool) ([]string, []string) {
 	allShortNames := []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
@@ -35,63 +30,6 @@ func getWeekdayNames(firstDayOfWeek time.Weekday, showWeekends bool) ([]string,
 	return dayShortNames, dayFullNames
 }
 
-// prepareColumnHeaders creates the column headers and their widths
-func prepareColumnHeaders(dayShortNames []string, dayFullNames []string, useShortDayNames bool, showCalendarWeek bool, showComments bool, justify string) ([]string, []int) {
-	var columnHeaders []string
-	var columnWidths []int
-
-	if showCalendarWeek {
-		if strings.ToLower(justify) == "center" {
-			columnHeaders = append(columnHeaders, "CW   ")
-			columnWidths = append(columnWidths, len("CW   "))
-		} else {
-			columnHeaders = append(columnHeaders, "CW  ")
-			columnWidths = append(columnWidths, len("CW  "))
-		}
-	}
-
-	// Use short day names if the option is set, otherwise use full day names
-	dayNames := dayFullNames
-	if useShortDayNames {
-		dayNames = dayShortNames
-	}
-
-	for _, d := range dayNames {
-		columnHeaders = append(columnHeaders, d)
-		columnWidths = append(columnWidths, len(d))
-	}
-
-	if showComments {
-		columnHeaders = append(columnHeaders, "Comments")
-		columnWidths = append(columnWidths, len("Comments"))
-	}
-
-	return columnHeaders, columnWidths
-}
-
-// generateTableHeader creates the header row and separator row for the Markdown table
-func generateTableHeader(columnHeaders []string, columnWidths []int, justify string) string {
-	var sb strings.Builder
-
-	// header row
-	sb.WriteString("|")
-	for i, h := range columnHeaders {
-		w := columnWidths[i]
-		sb.WriteString(" " + utils.PadRight(h, w) + " |")
-	}
-	sb.WriteString("\n")
-
-	// separator row
-	sb.WriteString("|")
-	for _, w := range columnWidths {
-		sep := utils.SeparatorCell(w, justify)
-		sb.WriteString(" " + sep + " |")
-	}
-	sb.WriteString("\n")
-
-	return sb.String()
-}
-
 // convertToWeekdays converts short day names to time.Weekday values
 func convertToWeekdays(dayShortNames []string) []time.Weekday {
 	var weekDays []time.Weekday
@@ -126,78 +64,20 @@ func calculateMonthBoundaries(year int, month time.Month, firstDayOfWeek time.We
 	return firstOfMonth, lastOfMonth, weekStart
 }
 
-// generateWeekRow creates a single week row for the calendar
-func generateWeekRow(cur time.Time, month time.Month, weekDays []time.Weekday, firstDayOfWeek time.Weekday,
-	columnWidths []int, showCalendarWeek bool, showComments bool) string {
-	var sb strings.Builder
-	var cells []string
-
-	if showCalendarWeek {
-		_, w := cur.ISOWeek()
-		cells = append(cells, fmt.Sprintf("_%d_", w))
-	}
-
-	for _, wd := range weekDays {
-		delta := (int(wd) - int(firstDayOfWeek) + 7) % 7
-		cd := cur.AddDate(0, 0, delta)
-		if cd.Month() == month {
-			cells = append(cells, fmt.Sprintf("%d", cd.Day()))
-		} else {
-			cells = append(cells, "")
-		}
-	}
-
-	if showComments {
-		cells = append(cells, "")
-	}
-
-	sb.WriteString("|")
-	for i, cell := range cells {
-		w := columnWidths[i]
-		sb.WriteString(" " + utils.PadRight(cell, w) + " |")
-	}
-	sb.WriteString("\n")
-
-	return sb.String()
-}
-
-// GenerateMonthCalendar generates a Markdown calendar for the specified month
+// GenerateMonthCalendar generates a calendar for the specified month using the renderer selected by options.Format
 func GenerateMonthCalendar(options Options) string {
-	var sb strings.Builder
-	month := time.Month(*options.Month)
-
-	// Add calendar header
-	sb.WriteString(generateCalendarHeader(options.Year, month))
-
-	// Get weekday names
-	dayShortNames, dayFullNames := getWeekdayNames(options.FirstDayOfWeek, options.ShowWeekends)
-
-	// Prepare column headers and widths
-	columnHeaders, columnWidths := prepareColumnHeaders(dayShortNames, dayFullNames, options.UseShortDayNames, options.ShowCalendarWeek,
-		options.ShowComments, options.Justify)
-
-	// Generate table header
-	sb.WriteString(generateTableHeader(columnHeaders, columnWidths, options.Justify))
-
-	// Convert short day names to weekdays
-	weekDays := convertToWeekdays(dayShortNames)
-
-	// Calculate month boundaries
-	_, lastOfMonth, weekStart := calculateMonthBoundaries(options.Year, month, options.FirstDayOfWeek)
-
-	// Generate each week row
-	for cur := weekStart; !cur.After(lastOfMonth); cur = cur.AddDate(0, 0, 7) {
-		sb.WriteString(generateWeekRow(cur, month, weekDays, options.FirstDayOfWeek,
-			columnWidths, options.ShowCalendarWeek, options.ShowComments))
+	renderer, ok := LookupRenderer(options.Format)
+	if !ok {
+		renderer = MarkdownRenderer{}
 	}
 
-	return sb.String()
+	return renderer.RenderMonth(BuildMonth(options), options)
 }
 
-// validateDateRange checks if the end date is after the start date
-func validateDateRange(options Options) (bool, string) {
+// validateDateRange checks that the end date is not before the start date
+func validateDateRange(options Options) error {
 	if options.EndYear == nil || options.EndMonth == nil {
-		return true, ""
+		return nil
 	}
 
 	monthValue := 1
@@ -209,40 +89,56 @@ func validateDateRange(options Options) (bool, string) {
 	endDate := time.Date(*options.EndYear, time.Month(*options.EndMonth), 1, 0, 0, 0, 0, time.UTC)
 
 	if startDate.After(endDate) {
-		return false, "Error: End date cannot be before start date\n"
+		return &ValidationError{
+			Field: "EndMonth",
+			Value: fmt.Sprintf("%04d-%02d", *options.EndYear, *options.EndMonth),
+			Err:   ErrRangeReversed,
+		}
 	}
 
-	return true, ""
+	return nil
 }
 
-// generateYearCalendar creates a calendar for the entire year
-func generateYearCalendar(options Options) string {
-	var result strings.Builder
+// writeMonth renders a single month to w, followed by a blank line when separate is set
+func writeMonth(w io.Writer, options Options, year int, month int, separate bool) error {
+	optionsCopy := options
+	optionsCopy.Year = year
+	monthValue := month
+	optionsCopy.Month = &monthValue
+
+	if _, err := io.WriteString(w, GenerateMonthCalendar(optionsCopy)); err != nil {
+		return err
+	}
+	if separate {
+		// Add a blank line between months
+		if _, err := io.WriteString(w, "\n"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
 
+// writeYearCalendar streams a calendar for the entire year, one month at a time
+func writeYearCalendar(w io.Writer, options Options) error {
 	for m := 1; m <= 12; m++ {
-		optionsCopy := options
-		monthValue := m
-		optionsCopy.Month = &monthValue
-		result.WriteString(GenerateMonthCalendar(optionsCopy))
-		result.WriteString("\n") // Add a blank line between months
+		if err := writeMonth(w, options, options.Year, m, true); err != nil {
+			return err
+		}
 	}
 
-	return result.String()
+	return nil
 }
 
-// generateMonthRangeCalendar creates a calendar for a range of months
-func generateMonthRangeCalendar(options Options) string {
-	var result strings.Builder
+// writeMonthRangeCalendar streams a calendar for a range of months, one month at a time
+func writeMonthRangeCalendar(w io.Writer, options Options) error {
 	currentYear := options.Year
 	currentMonth := *options.Month
 
 	for {
-		optionsCopy := options
-		optionsCopy.Year = currentYear
-		monthValue := currentMonth
-		optionsCopy.Month = &monthValue
-		result.WriteString(GenerateMonthCalendar(optionsCopy))
-		result.WriteString("\n") // Add a blank line between months
+		if err := writeMonth(w, options, currentYear, currentMonth, true); err != nil {
+			return err
+		}
 
 		// Move to the next month
 		currentMonth++
@@ -257,25 +153,83 @@ func generateMonthRangeCalendar(options Options) string {
 		}
 	}
 
-	return result.String()
+	return nil
 }
 
-// PrintCalendar generates and returns the calendar based on the provided options
-func PrintCalendar(options Options) string {
-	// Validate date range if the end date is specified
-	valid, errorMsg := validateDateRange(options)
-	if !valid {
-		return errorMsg
+// writeColumnsCalendar streams a calendar with options.Columns consecutive months side by side in each table
+func writeColumnsCalendar(w io.Writer, options Options) error {
+	renderer, _ := LookupRenderer(options.Format)
+	multi := renderer.(MultiMonthRenderer)
+	separate := options.Month == nil || (options.EndYear != nil && options.EndMonth != nil)
+
+	var months []Month
+	flush := func() error {
+		if len(months) == 0 {
+			return nil
+		}
+		output := multi.RenderMonths(months, options)
+		if separate {
+			// Add a blank line between tables
+			output += "\n"
+		}
+		months = months[:0]
+		_, err := io.WriteString(w, output)
+		return err
+	}
+
+	err := forEachMonth(options, func(firstOfMonth time.Time) error {
+		optionsCopy := options
+		optionsCopy.Year = firstOfMonth.Year()
+		monthValue := int(firstOfMonth.Month())
+		optionsCopy.Month = &monthValue
+		months = append(months, BuildMonth(optionsCopy))
+
+		if len(months) == options.Columns {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return flush()
+}
+
+// WriteCalendar generates the calendar based on the provided options and streams it to w month by month,
+// so that only a single month is held in memory at a time. Invalid options are reported as the error from
+// Options.Validate before anything is written; otherwise the first error encountered while writing is returned.
+func WriteCalendar(w io.Writer, options Options) error {
+	if err := options.Validate(); err != nil {
+		return err
+	}
+
+	if options.Layout != GridLayout {
+		return layouts[options.Layout](w, options)
+	}
+
+	if options.Columns > 1 {
+		// Generate calendar with months side by side
+		return writeColumnsCalendar(w, options)
 	}
 
 	if options.Month == nil {
 		// Generate calendar for the whole year
-		return generateYearCalendar(options)
+		return writeYearCalendar(w, options)
 	} else if options.EndYear != nil && options.EndMonth != nil {
 		// Generate calendar for a range of months
-		return generateMonthRangeCalendar(options)
+		return writeMonthRangeCalendar(w, options)
 	} else {
 		// Generate calendar for the specific month
-		return GenerateMonthCalendar(options)
+		return writeMonth(w, options, options.Year, *options.Month, false)
+	}
+}
+
+// PrintCalendar generates and returns the calendar based on the provided options
+func PrintCalendar(options Options) (string, error) {
+	var sb strings.Builder
+	if err := WriteCalendar(&sb, options); err != nil {
+		return "", err
 	}
+	return sb.String(), nil
 }
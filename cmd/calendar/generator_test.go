@@ -1,43 +1,16 @@
 package calendar
 
 import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
 
-func TestGenerateCalendarHeader(t *testing.T) {
-	tests := []struct {
-		name     string
-		year     int
-		month    time.Month
-		expected string
-	}{
-		{
-			name:     "January 2023",
-			year:     2023,
-			month:    time.January,
-			expected: "# January 2023\n\n",
-		},
-		{
-			name:     "December 2025",
-			year:     2025,
-			month:    time.December,
-			expected: "# December 2025\n\n",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			actual := generateCalendarHeader(tt.year, tt.month)
-			if diff := cmp.Diff(tt.expected, actual); diff != "" {
-				t.Errorf("generateCalendarHeader(%d, %v) mismatch (-want +got):\n%s", tt.year, tt.month, diff)
-			}
-		})
-	}
-}
-
 func TestGetWeekdayNames(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -98,132 +71,6 @@ func TestGetWeekdayNames(t *testing.T) {
 	}
 }
 
-func TestPrepareColumnHeaders(t *testing.T) {
-	tests := []struct {
-		name             string
-		dayShortNames    []string
-		dayFullNames     []string
-		useShortDayNames bool
-		showCalendarWeek bool
-		showComments     bool
-		justify          string
-		expectedHeaders  []string
-		expectedWidths   []int
-	}{
-		{
-			name:             "Short names, with week numbers and comments",
-			dayShortNames:    []string{"Mon", "Tue", "Wed", "Thu", "Fri"},
-			dayFullNames:     []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"},
-			useShortDayNames: true,
-			showCalendarWeek: true,
-			showComments:     true,
-			justify:          "left",
-			expectedHeaders:  []string{"CW  ", "Mon", "Tue", "Wed", "Thu", "Fri", "Comments"},
-			expectedWidths:   []int{4, 3, 3, 3, 3, 3, 8},
-		},
-		{
-			name:             "Full names, with week numbers and comments",
-			dayShortNames:    []string{"Mon", "Tue", "Wed", "Thu", "Fri"},
-			dayFullNames:     []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"},
-			useShortDayNames: false,
-			showCalendarWeek: true,
-			showComments:     true,
-			justify:          "left",
-			expectedHeaders:  []string{"CW  ", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Comments"},
-			expectedWidths:   []int{4, 6, 7, 9, 8, 6, 8},
-		},
-		{
-			name:             "Short names, no week numbers, with comments",
-			dayShortNames:    []string{"Mon", "Tue", "Wed", "Thu", "Fri"},
-			dayFullNames:     []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"},
-			useShortDayNames: true,
-			showCalendarWeek: false,
-			showComments:     true,
-			justify:          "left",
-			expectedHeaders:  []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Comments"},
-			expectedWidths:   []int{3, 3, 3, 3, 3, 8},
-		},
-		{
-			name:             "Short names, with week numbers, no comments",
-			dayShortNames:    []string{"Mon", "Tue", "Wed", "Thu", "Fri"},
-			dayFullNames:     []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"},
-			useShortDayNames: true,
-			showCalendarWeek: true,
-			showComments:     false,
-			justify:          "left",
-			expectedHeaders:  []string{"CW  ", "Mon", "Tue", "Wed", "Thu", "Fri"},
-			expectedWidths:   []int{4, 3, 3, 3, 3, 3},
-		},
-		{
-			name:             "Center justify",
-			dayShortNames:    []string{"Mon", "Tue", "Wed", "Thu", "Fri"},
-			dayFullNames:     []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"},
-			useShortDayNames: true,
-			showCalendarWeek: true,
-			showComments:     true,
-			justify:          "center",
-			expectedHeaders:  []string{"CW   ", "Mon", "Tue", "Wed", "Thu", "Fri", "Comments"},
-			expectedWidths:   []int{5, 3, 3, 3, 3, 3, 8},
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			headers, widths := prepareColumnHeaders(tt.dayShortNames, tt.dayFullNames, tt.useShortDayNames,
-				tt.showCalendarWeek, tt.showComments, tt.justify)
-
-			if diff := cmp.Diff(tt.expectedHeaders, headers); diff != "" {
-				t.Errorf("prepareColumnHeaders() headers mismatch (-want +got):\n%s", diff)
-			}
-
-			if diff := cmp.Diff(tt.expectedWidths, widths); diff != "" {
-				t.Errorf("prepareColumnHeaders() widths mismatch (-want +got):\n%s", diff)
-			}
-		})
-	}
-}
-
-func TestGenerateTableHeader(t *testing.T) {
-	tests := []struct {
-		name          string
-		columnHeaders []string
-		columnWidths  []int
-		justify       string
-		expected      string
-	}{
-		{
-			name:          "Left justify",
-			columnHeaders: []string{"CW", "Mon", "Tue", "Wed"},
-			columnWidths:  []int{2, 3, 3, 3},
-			justify:       "left",
-			expected:      "| CW | Mon | Tue | Wed |\n| :- | :-- | :-- | :-- |\n",
-		},
-		{
-			name:          "Center justify",
-			columnHeaders: []string{"CW", "Mon", "Tue", "Wed"},
-			columnWidths:  []int{2, 3, 3, 3},
-			justify:       "center",
-			expected:      "| CW | Mon | Tue | Wed |\n| :-: | :-: | :-: | :-: |\n",
-		},
-		{
-			name:          "Right justify",
-			columnHeaders: []string{"CW", "Mon", "Tue", "Wed"},
-			columnWidths:  []int{2, 3, 3, 3},
-			justify:       "right",
-			expected:      "| CW | Mon | Tue | Wed |\n| -: | --: | --: | --: |\n",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			actual := generateTableHeader(tt.columnHeaders, tt.columnWidths, tt.justify)
-			if diff := cmp.Diff(tt.expected, actual); diff != "" {
-				t.Errorf("generateTableHeader() mismatch (-want +got):\n%s", diff)
-			}
-		})
-	}
-}
-
 func TestConvertToWeekdays(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -314,48 +161,11 @@ func TestCalculateMonthBoundaries(t *testing.T) {
 	}
 }
 
-func TestGenerateWeekRow(t *testing.T) {
-	tests := []struct {
-		name             string
-		cur              time.Time
-		month            time.Month
-		weekDays         []time.Weekday
-		firstDayOfWeek   time.Weekday
-		columnWidths     []int
-		showCalendarWeek bool
-		showComments     bool
-		expected         string
-	}{
-		{
-			name:             "First week of January 2023, Monday first",
-			cur:              time.Date(2022, time.December, 26, 0, 0, 0, 0, time.UTC),
-			month:            time.January,
-			weekDays:         []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
-			firstDayOfWeek:   time.Monday,
-			columnWidths:     []int{4, 3, 3, 3, 3, 3, 8}, // Need 7 elements: 1 for week number, 5 for weekdays, 1 for comments
-			showCalendarWeek: true,
-			showComments:     true,
-			expected:         "| _52_ |     |     |     |     |     |          |\n",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			actual := generateWeekRow(tt.cur, tt.month, tt.weekDays, tt.firstDayOfWeek,
-				tt.columnWidths, tt.showCalendarWeek, tt.showComments)
-			if diff := cmp.Diff(tt.expected, actual); diff != "" {
-				t.Errorf("generateWeekRow() mismatch (-want +got):\n%s", diff)
-			}
-		})
-	}
-}
-
 func TestValidateDateRange(t *testing.T) {
 	tests := []struct {
-		name          string
-		options       Options
-		expectedValid bool
-		expectedMsg   string
+		name        string
+		options     Options
+		expectedErr error
 	}{
 		{
 			name: "No end date specified",
@@ -363,8 +173,7 @@ func TestValidateDateRange(t *testing.T) {
 				Year:  2023,
 				Month: intPtr(5),
 			},
-			expectedValid: true,
-			expectedMsg:   "",
+			expectedErr: nil,
 		},
 		{
 			name: "Valid date range (same year)",
@@ -374,8 +183,7 @@ func TestValidateDateRange(t *testing.T) {
 				EndYear:  intPtr(2023),
 				EndMonth: intPtr(7),
 			},
-			expectedValid: true,
-			expectedMsg:   "",
+			expectedErr: nil,
 		},
 		{
 			name: "Valid date range (different years)",
@@ -385,8 +193,7 @@ func TestValidateDateRange(t *testing.T) {
 				EndYear:  intPtr(2024),
 				EndMonth: intPtr(2),
 			},
-			expectedValid: true,
-			expectedMsg:   "",
+			expectedErr: nil,
 		},
 		{
 			name: "Invalid date range (end before start)",
@@ -396,8 +203,7 @@ func TestValidateDateRange(t *testing.T) {
 				EndYear:  intPtr(2023),
 				EndMonth: intPtr(3),
 			},
-			expectedValid: false,
-			expectedMsg:   "Error: End date cannot be before start date\n",
+			expectedErr: ErrRangeReversed,
 		},
 		{
 			name: "Invalid date range (different years)",
@@ -407,22 +213,178 @@ func TestValidateDateRange(t *testing.T) {
 				EndYear:  intPtr(2022),
 				EndMonth: intPtr(7),
 			},
-			expectedValid: false,
-			expectedMsg:   "Error: End date cannot be before start date\n",
+			expectedErr: ErrRangeReversed,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			valid, msg := validateDateRange(tt.options)
+			err := validateDateRange(tt.options)
+
+			if !errors.Is(err, tt.expectedErr) || (err == nil) != (tt.expectedErr == nil) {
+				t.Errorf("validateDateRange() error = %v, want %v", err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestWriteCalendar(t *testing.T) {
+	options := NewOptions()
+	options.Year = 2025
+	options.Month = intPtr(11)
+	options.EndYear = intPtr(2026)
+	options.EndMonth = intPtr(2)
+
+	var sb strings.Builder
+	if err := WriteCalendar(&sb, options); err != nil {
+		t.Fatalf("WriteCalendar() returned error: %v", err)
+	}
+
+	expected, err := PrintCalendar(options)
+	if err != nil {
+		t.Fatalf("PrintCalendar() returned error: %v", err)
+	}
+
+	if diff := cmp.Diff(expected, sb.String()); diff != "" {
+		t.Errorf("WriteCalendar() mismatch with PrintCalendar() (-want +got):\n%s", diff)
+	}
+
+	if count := strings.Count(sb.String(), "\n# "); count != 3 {
+		t.Errorf("WriteCalendar() wrote %d month headings after the first, want 3", count)
+	}
+}
+
+// failingWriter accepts a fixed number of writes and then fails
+type failingWriter struct {
+	remaining int
+	writes    int
+}
+
+func (f *failingWriter) Write(p []byte) (int, error) {
+	f.writes++
+	if f.remaining == 0 {
+		return 0, errors.New("disk full")
+	}
+	f.remaining--
+	return len(p), nil
+}
 
-			if valid != tt.expectedValid {
-				t.Errorf("validateDateRange() valid = %v, want %v", valid, tt.expectedValid)
+func TestWriteCalendarColumns(t *testing.T) {
+	options := NewOptions()
+	options.Year = 2025
+	options.Columns = 4
+
+	output, err := PrintCalendar(options)
+	if err != nil {
+		t.Fatalf("PrintCalendar() returned error: %v", err)
+	}
+
+	headings := []string{"# January – April 2025\n", "# May – August 2025\n", "# September – December 2025\n"}
+	for _, heading := range headings {
+		if !strings.Contains(output, heading) {
+			t.Errorf("PrintCalendar() output is missing heading %q", heading)
+		}
+	}
+	if count := strings.Count(output, "\n# "); count != 2 {
+		t.Errorf("PrintCalendar() wrote %d tables after the first, want 2", count)
+	}
+}
+
+func TestWriteCalendarInvalidOptions(t *testing.T) {
+	options := NewOptions()
+	options.Month = intPtr(5)
+	options.EndYear = intPtr(options.Year - 1)
+	options.EndMonth = intPtr(5)
+
+	w := &failingWriter{remaining: 1}
+	if err := WriteCalendar(w, options); !errors.Is(err, ErrRangeReversed) {
+		t.Errorf("WriteCalendar() error = %v, want %v", err, ErrRangeReversed)
+	}
+
+	if w.writes != 0 {
+		t.Errorf("WriteCalendar() wrote %d times for invalid options, want 0", w.writes)
+	}
+}
+
+func TestWriteCalendarStopsOnWriteError(t *testing.T) {
+	options := NewOptions()
+	options.Year = 2025
+
+	w := &failingWriter{remaining: 2}
+	err := WriteCalendar(w, options)
+	if err == nil || err.Error() != "disk full" {
+		t.Fatalf("WriteCalendar() error = %v, want disk full", err)
+	}
+
+	if w.writes != 3 {
+		t.Errorf("WriteCalendar() attempted %d writes, want 3", w.writes)
+	}
+}
+
+// heapSamplingWriter discards its input and records the peak heap usage seen between writes
+type heapSamplingWriter struct {
+	writes   int
+	peakHeap uint64
+}
+
+func (h *heapSamplingWriter) Write(p []byte) (int, error) {
+	h.writes++
+	if h.writes%100 == 0 {
+		var stats runtime.MemStats
+		runtime.ReadMemStats(&stats)
+		if stats.HeapAlloc > h.peakHeap {
+			h.peakHeap = stats.HeapAlloc
+		}
+	}
+	return len(p), nil
+}
+
+func benchmarkRangeOptions(endYear int) Options {
+	options := NewOptions()
+	options.Year = 1
+	options.Month = intPtr(1)
+	options.EndYear = intPtr(endYear)
+	options.EndMonth = intPtr(12)
+	return options
+}
+
+func BenchmarkWriteCalendar(b *testing.B) {
+	for _, endYear := range []int{10, 100, 9999} {
+		b.Run(fmt.Sprintf("years=%d", endYear), func(b *testing.B) {
+			options := benchmarkRangeOptions(endYear)
+			b.ReportAllocs()
+
+			var peak uint64
+			for i := 0; i < b.N; i++ {
+				w := &heapSamplingWriter{}
+				if err := WriteCalendar(w, options); err != nil {
+					b.Fatal(err)
+				}
+				peak = max(peak, w.peakHeap)
 			}
+			b.ReportMetric(float64(peak), "peak-heap-B")
+		})
+	}
+}
+
+func BenchmarkPrintCalendar(b *testing.B) {
+	for _, endYear := range []int{10, 100, 9999} {
+		b.Run(fmt.Sprintf("years=%d", endYear), func(b *testing.B) {
+			options := benchmarkRangeOptions(endYear)
+			b.ReportAllocs()
 
-			if msg != tt.expectedMsg {
-				t.Errorf("validateDateRange() msg = %q, want %q", msg, tt.expectedMsg)
+			var peak uint64
+			for i := 0; i < b.N; i++ {
+				out, err := PrintCalendar(options)
+				if err != nil {
+					b.Fatal(err)
+				}
+				var stats runtime.MemStats
+				runtime.ReadMemStats(&stats)
+				peak = max(peak, stats.HeapAlloc)
+				runtime.KeepAlive(out)
 			}
+			b.ReportMetric(float64(peak), "peak-heap-B")
 		})
 	}
 }
@@ -0,0 +1,97 @@
+package calendar
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+func init() {
+	layouts["habits"] = writeHabitsCalendar
+}
+
+// habitCheckbox is the cell of a habit on a day when Checkboxes is set
+const habitCheckbox = "[ ]"
+
+// generateHabitsMonth creates the habit tracker table for a single month, with one row per displayed day,
+// one column per habit and a totals row
+func generateHabitsMonth(firstOfMonth time.Time, options Options) string {
+	var sb strings.Builder
+
+	sb.WriteString(generateCalendarHeader(firstOfMonth.Year(), firstOfMonth.Month()))
+
+	columnHeaders := []string{"Date", "Weekday"}
+	if options.ShowCalendarWeek {
+		columnHeaders = append(columnHeaders, "CW")
+	}
+	for _, habit := range options.Habits {
+		columnHeaders = append(columnHeaders, escapeCell(habit))
+	}
+	if options.ShowComments {
+		columnHeaders = append(columnHeaders, "Comments")
+	}
+
+	cell := ""
+	if options.Checkboxes {
+		cell = habitCheckbox
+	}
+
+	var rows [][]string
+	firstDay, lastOfMonth, _ := calculateMonthBoundaries(firstOfMonth.Year(), firstOfMonth.Month(), options.FirstDayOfWeek)
+	for date := firstDay; !date.After(lastOfMonth); date = date.AddDate(0, 0, 1) {
+		if !options.ShowWeekends && isWeekend(date.Weekday()) {
+			continue
+		}
+
+		weekday := date.Weekday().String()
+		if options.UseShortDayNames {
+			weekday = weekday[:3]
+		}
+		row := []string{options.dayLabel(date, date.Format(time.DateOnly)), weekday}
+		if options.ShowCalendarWeek {
+			row = append(row, "_"+options.weekLabel(date)+"_")
+		}
+		for range options.Habits {
+			row = append(row, cell)
+		}
+		if options.ShowComments {
+			row = append(row, "")
+		}
+		rows = append(rows, row)
+	}
+
+	// The totals row counts the tracked days, leaving the number of completed days to fill in
+	totals := []string{"**Total**", ""}
+	if options.ShowCalendarWeek {
+		totals = append(totals, "")
+	}
+	for range options.Habits {
+		totals = append(totals, fmt.Sprintf("/ %d", len(rows)))
+	}
+	if options.ShowComments {
+		totals = append(totals, "")
+	}
+	rows = append(rows, totals)
+
+	sb.WriteString(generateTable(columnHeaders, rows, options.Justify))
+
+	return sb.String()
+}
+
+// writeHabitsCalendar streams a habit tracker with one table per month of the selected range
+func writeHabitsCalendar(w io.Writer, options Options) error {
+	first := true
+
+	return forEachMonth(options, func(firstOfMonth time.Time) error {
+		output := generateHabitsMonth(firstOfMonth, options)
+		if !first {
+			// Add a blank line between months
+			output = "\n" + output
+		}
+		first = false
+
+		_, err := io.WriteString(w, output)
+		return err
+	})
+}
@@ -0,0 +1,52 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGenerateHabitsMonth(t *testing.T) {
+	options := NewOptions()
+	options.UseShortDayNames = true
+	options.ShowCalendarWeek = false
+	options.ShowComments = false
+	options.Checkboxes = true
+	options.Habits = []string{"Run", "Read|Write"}
+
+	output := generateHabitsMonth(time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC), options)
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+
+	expectedStart := []string{
+		"# February 2025",
+		"",
+		"| Date       | Weekday | Run  | Read\\|Write |",
+		"| :--------- | :------ | :--- | :---------- |",
+		"| 2025-02-01 | Sat     | [ ]  | [ ]         |",
+	}
+	if diff := cmp.Diff(expectedStart, lines[:5]); diff != "" {
+		t.Errorf("generateHabitsMonth() start mismatch (-want +got):\n%s", diff)
+	}
+
+	expectedTotals := "| **Total**  |         | / 28 | / 28        |"
+	if actual := lines[len(lines)-1]; actual != expectedTotals {
+		t.Errorf("generateHabitsMonth() totals = %q, want %q", actual, expectedTotals)
+	}
+}
+
+func TestGenerateHabitsMonthWorkweek(t *testing.T) {
+	options := NewOptions()
+	options.ShowWeekends = false
+	options.Habits = []string{"Meditate"}
+
+	output := generateHabitsMonth(time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC), options)
+
+	if strings.Contains(output, "Saturday") || strings.Contains(output, "Sunday") {
+		t.Errorf("generateHabitsMonth() includes weekends:\n%s", output)
+	}
+	if !strings.Contains(output, "| / 20 ") {
+		t.Errorf("generateHabitsMonth() totals should count 20 weekdays:\n%s", output)
+	}
+}
@@ -0,0 +1,286 @@
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"github.com/andre-a-alves/mdcal/cmd/utils"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HeatmapGlyphs are the shades of a heatmap cell
+type HeatmapGlyphs struct {
+	NoData string   // day without a value
+	Zero   string   // day with a value of zero or less
+	Levels []string // days with a positive value, from the lowest level to the highest
+}
+
+// heatmapGlyphs maps the names accepted by Heatmap.Glyphs to their shades
+var heatmapGlyphs = map[string]HeatmapGlyphs{
+	"blocks":  {NoData: "·", Zero: "░", Levels: []string{"▒", "▓", "█"}},
+	"squares": {NoData: "·", Zero: "⬜", Levels: []string{"🟩", "🟨", "🟧", "🟥"}},
+}
+
+// heatmapPeriods lists the accepted values of Heatmap.Period
+var heatmapPeriods = []string{"month", "year"}
+
+// Heatmap configures a calendar that shades each day by its value
+type Heatmap struct {
+	Values     map[string]float64 // values keyed by date in time.DateOnly format; days without a key have no data
+	Glyphs     string             // name of the glyph set, see HeatmapGlyphNames
+	Thresholds []float64          // lower bounds of the levels above the first; nil buckets the values by quantile
+	Period     string             // "month" for a grid per month, "year" for weekdays as rows and weeks as columns
+}
+
+// HeatmapGlyphNames returns the sorted names of the available glyph sets
+func HeatmapGlyphNames() []string {
+	var names []string
+	for name := range heatmapGlyphs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Validate checks the heatmap for values that cannot produce a calendar, reporting all problems together
+func (h Heatmap) Validate() error {
+	var errs []error
+
+	glyphs, ok := heatmapGlyphs[h.Glyphs]
+	if !ok {
+		errs = append(errs, &ValidationError{
+			Field:      "Glyphs",
+			Value:      h.Glyphs,
+			Suggestion: utils.Suggest(h.Glyphs, HeatmapGlyphNames()),
+			Err:        ErrUnknownGlyphs,
+		})
+	}
+	if !slices.Contains(heatmapPeriods, h.Period) {
+		errs = append(errs, &ValidationError{
+			Field:      "Period",
+			Value:      h.Period,
+			Suggestion: utils.Suggest(h.Period, heatmapPeriods),
+			Err:        ErrInvalidPeriod,
+		})
+	}
+
+	thresholdsValid := ok && len(h.Thresholds) < len(glyphs.Levels)
+	for i, t := range h.Thresholds {
+		if t <= 0 || (i > 0 && t <= h.Thresholds[i-1]) {
+			thresholdsValid = false
+		}
+	}
+	if ok && !thresholdsValid {
+		errs = append(errs, &ValidationError{Field: "Thresholds", Value: formatThresholds(h.Thresholds), Err: ErrInvalidThresholds})
+	}
+
+	return errors.Join(errs...)
+}
+
+// formatThresholds formats thresholds as a comma separated list
+func formatThresholds(thresholds []float64) string {
+	values := make([]string, len(thresholds))
+	for i, t := range thresholds {
+		values[i] = strconv.FormatFloat(t, 'g', -1, 64)
+	}
+	return strings.Join(values, ",")
+}
+
+// quantileThresholds splits the positive values between start and end into levels of roughly equal size,
+// with fewer levels when many days share a value
+func quantileThresholds(values map[string]float64, start time.Time, end time.Time, levels int) []float64 {
+	var positive []float64
+	for key, v := range values {
+		date, err := time.Parse(time.DateOnly, key)
+		if err != nil || date.Before(start) || date.After(end) || v <= 0 {
+			continue
+		}
+		positive = append(positive, v)
+	}
+	sort.Float64s(positive)
+
+	var thresholds []float64
+	for i := 1; i < levels && len(positive) > 0; i++ {
+		lower := positive[0]
+		if len(thresholds) > 0 {
+			lower = thresholds[len(thresholds)-1]
+		}
+		// Move past values shared with the level below so that every threshold starts a new level
+		j := i * len(positive) / levels
+		for j < len(positive) && positive[j] <= lower {
+			j++
+		}
+		if j == len(positive) {
+			break
+		}
+		thresholds = append(thresholds, positive[j])
+	}
+
+	return thresholds
+}
+
+// heatmapScale maps values to the glyphs of their level
+type heatmapScale struct {
+	values     map[string]float64
+	glyphs     HeatmapGlyphs
+	thresholds []float64
+}
+
+// glyph returns the shade of the date
+func (s heatmapScale) glyph(date time.Time) string {
+	v, ok := s.values[date.Format(time.DateOnly)]
+	switch {
+	case !ok:
+		return s.glyphs.NoData
+	case v <= 0:
+		return s.glyphs.Zero
+	}
+
+	level := 0
+	for _, t := range s.thresholds {
+		if v >= t {
+			level++
+		}
+	}
+	return s.glyphs.Levels[level]
+}
+
+// legend describes the glyphs in use, such as "·: no data, ░: 0, ▒: > 0, ▓: ≥ 3"
+func (s heatmapScale) legend() string {
+	parts := []string{s.glyphs.NoData + ": no data", s.glyphs.Zero + ": 0", s.glyphs.Levels[0] + ": > 0"}
+	for i, t := range s.thresholds {
+		parts = append(parts, s.glyphs.Levels[i+1]+": ≥ "+strconv.FormatFloat(t, 'g', -1, 64))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// generateHeatmapMonth creates the grid of a month with the shade of each day next to its number
+func generateHeatmapMonth(month Month, options Options, scale heatmapScale) string {
+	var sb strings.Builder
+
+	sb.WriteString(generateCalendarHeader(month.Year, month.Month))
+
+	dayShortNames, dayFullNames := weekdayNames(month.Weekdays)
+	columnHeaders, _ := prepareColumnHeaders(dayShortNames, dayFullNames, options.UseShortDayNames, options.ShowCalendarWeek,
+		options.ShowComments, options.Justify)
+
+	var rows [][]string
+	for _, week := range month.Weeks {
+		var row []string
+		if options.ShowCalendarWeek {
+			row = append(row, "_"+week.Label+"_")
+		}
+		for _, day := range week.Days {
+			if day.InMonth {
+				row = append(row, day.Label+" "+scale.glyph(day.Date)+formatAnnotations(day.Annotations))
+			} else {
+				row = append(row, "")
+			}
+		}
+		if options.ShowComments {
+			row = append(row, "")
+		}
+		rows = append(rows, row)
+	}
+
+	sb.WriteString(generateTable(columnHeaders, rows, options.Justify))
+
+	return sb.String()
+}
+
+// generateHeatmapYear creates a contribution graph style table of the days between start and end,
+// which lie in the same year, with the weekdays as rows and the weeks as columns headed by the months they start
+func generateHeatmapYear(start time.Time, end time.Time, options Options, scale heatmapScale) string {
+	dayShortNames, dayFullNames := getWeekdayNames(options.FirstDayOfWeek, options.ShowWeekends)
+	weekDays := convertToWeekdays(dayShortNames)
+	dayNames := dayFullNames
+	if options.UseShortDayNames {
+		dayNames = dayShortNames
+	}
+
+	shift := (int(start.Weekday()) - int(options.FirstDayOfWeek) + 7) % 7
+	var weekStarts []time.Time
+	for cur := start.AddDate(0, 0, -shift); !cur.After(end); cur = cur.AddDate(0, 0, 7) {
+		weekStarts = append(weekStarts, cur)
+	}
+
+	// Empty headers are two spaces wide so that single column glyphs still get a valid separator
+	columnHeaders := []string{"  "}
+	for _, weekStart := range weekStarts {
+		header := "  "
+		for d := weekStart; d.Before(weekStart.AddDate(0, 0, 7)); d = d.AddDate(0, 0, 1) {
+			if d.Day() == 1 && !d.Before(start) && !d.After(end) {
+				header = d.Month().String()[:3]
+			}
+		}
+		columnHeaders = append(columnHeaders, header)
+	}
+
+	var rows [][]string
+	for i, wd := range weekDays {
+		row := []string{"**" + dayNames[i] + "**"}
+		delta := (int(wd) - int(options.FirstDayOfWeek) + 7) % 7
+		for _, weekStart := range weekStarts {
+			date := weekStart.AddDate(0, 0, delta)
+			if date.Before(start) || date.After(end) {
+				row = append(row, "")
+			} else {
+				row = append(row, scale.glyph(date))
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return fmt.Sprintf("# %d\n\n%s", start.Year(), generateTable(columnHeaders, rows, options.Justify))
+}
+
+// WriteHeatmap streams a calendar of the range selected by options where each day is shaded by its value
+// in heatmap, followed by a legend of the shades. Invalid options or heatmap settings are reported before
+// anything is written, as are other layouts, columns and transposing, which heatmaps do not support.
+func WriteHeatmap(w io.Writer, options Options, heatmap Heatmap) error {
+	if err := errors.Join(options.Validate(), validateOwnLayout(options, ErrUnsupportedHeatmap), heatmap.Validate()); err != nil {
+		return err
+	}
+
+	start, end := options.DateRange()
+	scale := heatmapScale{values: heatmap.Values, glyphs: heatmapGlyphs[heatmap.Glyphs], thresholds: heatmap.Thresholds}
+	if scale.thresholds == nil {
+		scale.thresholds = quantileThresholds(heatmap.Values, start, end, len(scale.glyphs.Levels))
+	}
+
+	var err error
+	if heatmap.Period == "year" {
+		for year := start.Year(); year <= end.Year() && err == nil; year++ {
+			yearStart := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+			yearEnd := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+			if yearStart.Before(start) {
+				yearStart = start
+			}
+			if yearEnd.After(end) {
+				yearEnd = end
+			}
+			_, err = io.WriteString(w, generateHeatmapYear(yearStart, yearEnd, options, scale)+"\n")
+		}
+	} else {
+		err = forEachMonth(options, func(firstOfMonth time.Time) error {
+			optionsCopy := options
+			optionsCopy.Year = firstOfMonth.Year()
+			monthValue := int(firstOfMonth.Month())
+			optionsCopy.Month = &monthValue
+
+			_, err := io.WriteString(w, generateHeatmapMonth(BuildMonth(optionsCopy), optionsCopy, scale)+"\n")
+			return err
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, scale.legend()+"\n")
+	return err
+}
@@ -0,0 +1,208 @@
+package calendar
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestQuantileThresholds(t *testing.T) {
+	start := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		values   map[string]float64
+		expected []float64
+	}{
+		{
+			name: "Equal levels",
+			values: map[string]float64{
+				"2025-03-01": 1, "2025-03-02": 2, "2025-03-03": 3, "2025-03-04": 4, "2025-03-05": 5, "2025-03-06": 6,
+				"2025-03-07": 0, "2025-04-01": 100,
+			},
+			expected: []float64{3, 5},
+		},
+		{
+			name:     "Repeated values",
+			values:   map[string]float64{"2025-03-01": 1, "2025-03-02": 1, "2025-03-03": 1, "2025-03-04": 9},
+			expected: []float64{9},
+		},
+		{
+			name:     "No positive values",
+			values:   map[string]float64{"2025-03-01": 0},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := quantileThresholds(tt.values, start, end, 3)
+			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+				t.Errorf("quantileThresholds() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHeatmapScale(t *testing.T) {
+	scale := heatmapScale{
+		values:     map[string]float64{"2025-03-01": 0, "2025-03-02": 1, "2025-03-03": 5, "2025-03-04": 12},
+		glyphs:     heatmapGlyphs["blocks"],
+		thresholds: []float64{5, 10},
+	}
+
+	var actual []string
+	for d := 0; d <= 4; d++ {
+		actual = append(actual, scale.glyph(time.Date(2025, time.February, 28+d, 0, 0, 0, 0, time.UTC)))
+	}
+	if diff := cmp.Diff([]string{"·", "░", "▒", "▓", "█"}, actual); diff != "" {
+		t.Errorf("glyph() mismatch (-want +got):\n%s", diff)
+	}
+
+	expectedLegend := "·: no data, ░: 0, ▒: > 0, ▓: ≥ 5, █: ≥ 10"
+	if legend := scale.legend(); legend != expectedLegend {
+		t.Errorf("legend() = %q, want %q", legend, expectedLegend)
+	}
+}
+
+func TestHeatmapValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		heatmap  Heatmap
+		expected []error
+	}{
+		{
+			name:    "Valid",
+			heatmap: Heatmap{Glyphs: "squares", Period: "year", Thresholds: []float64{1, 5, 10}},
+		},
+		{
+			name:     "Unknown glyphs and period",
+			heatmap:  Heatmap{Glyphs: "square", Period: "week"},
+			expected: []error{ErrUnknownGlyphs, ErrInvalidPeriod},
+		},
+		{
+			name:     "Too many thresholds",
+			heatmap:  Heatmap{Glyphs: "blocks", Period: "month", Thresholds: []float64{1, 5, 10}},
+			expected: []error{ErrInvalidThresholds},
+		},
+		{
+			name:     "Decreasing thresholds",
+			heatmap:  Heatmap{Glyphs: "blocks", Period: "month", Thresholds: []float64{5, 1}},
+			expected: []error{ErrInvalidThresholds},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.heatmap.Validate()
+			if len(tt.expected) == 0 && err != nil {
+				t.Fatalf("Validate() unexpected error: %v", err)
+			}
+			for _, expected := range tt.expected {
+				if !errors.Is(err, expected) {
+					t.Errorf("Validate() error = %v, want %v", err, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteHeatmap(t *testing.T) {
+	month := 2
+	options := NewOptions()
+	options.Year = 2025
+	options.Month = &month
+	options.ShowComments = false
+	options.UseShortDayNames = true
+
+	heatmap := Heatmap{
+		Values: map[string]float64{"2025-02-03": 0, "2025-02-04": 2, "2025-02-05": 7},
+		Glyphs: "blocks",
+		Period: "month",
+	}
+
+	var sb strings.Builder
+	if err := WriteHeatmap(&sb, options, heatmap); err != nil {
+		t.Fatalf("WriteHeatmap() unexpected error: %v", err)
+	}
+	lines := strings.Split(sb.String(), "\n")
+
+	expectedStart := []string{
+		"# February 2025",
+		"",
+		"| CW   | Mon  | Tue  | Wed  | Thu  | Fri  | Sat  | Sun  |",
+		"| :--- | :--- | :--- | :--- | :--- | :--- | :--- | :--- |",
+		"| _5_  |      |      |      |      |      | 1 ·  | 2 ·  |",
+		"| _6_  | 3 ░  | 4 ▒  | 5 ▓  | 6 ·  | 7 ·  | 8 ·  | 9 ·  |",
+	}
+	if diff := cmp.Diff(expectedStart, lines[:6]); diff != "" {
+		t.Errorf("WriteHeatmap() start mismatch (-want +got):\n%s", diff)
+	}
+
+	expectedEnd := []string{"", "·: no data, ░: 0, ▒: > 0, ▓: ≥ 7", ""}
+	if diff := cmp.Diff(expectedEnd, lines[len(lines)-3:]); diff != "" {
+		t.Errorf("WriteHeatmap() legend mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWriteHeatmapYear(t *testing.T) {
+	options := NewOptions()
+	options.Year = 2025
+	options.UseShortDayNames = true
+
+	heatmap := Heatmap{Values: map[string]float64{"2025-01-01": 3}, Glyphs: "blocks", Period: "year"}
+
+	var sb strings.Builder
+	if err := WriteHeatmap(&sb, options, heatmap); err != nil {
+		t.Fatalf("WriteHeatmap() unexpected error: %v", err)
+	}
+	lines := strings.Split(sb.String(), "\n")
+
+	if lines[0] != "# 2025" {
+		t.Errorf("WriteHeatmap() heading = %q, want %q", lines[0], "# 2025")
+	}
+	// 2025 starts on a Wednesday and ends on a Wednesday, spanning 53 Monday based weeks
+	if cells := strings.Count(lines[2], "|") - 1; cells != 54 {
+		t.Errorf("WriteHeatmap() has %d columns, want 54", cells)
+	}
+	if !strings.HasPrefix(lines[2], "|         | Jan | ") {
+		t.Errorf("WriteHeatmap() header = %q, want the first week headed by Jan", lines[2])
+	}
+	if !strings.HasPrefix(lines[4], "| **Mon** |     |") || !strings.HasPrefix(lines[6], "| **Wed** | ▒   |") {
+		t.Errorf("WriteHeatmap() first week mismatch:\n%s", sb.String())
+	}
+}
+
+func TestWriteHeatmapUnsupportedOptions(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(o *Options)
+		field  string
+	}{
+		{name: "Layout", modify: func(o *Options) { o.Layout = "agenda" }, field: "Layout"},
+		{name: "Columns", modify: func(o *Options) { o.Columns = 3 }, field: "Columns"},
+		{name: "Transpose", modify: func(o *Options) { o.Transpose = true }, field: "Transpose"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := NewOptions()
+			options.Year = 2025
+			tt.modify(&options)
+
+			var sb strings.Builder
+			err := WriteHeatmap(&sb, options, Heatmap{Glyphs: "blocks", Period: "month"})
+			var validationErr *ValidationError
+			if !errors.Is(err, ErrUnsupportedHeatmap) || !errors.As(err, &validationErr) || validationErr.Field != tt.field {
+				t.Fatalf("WriteHeatmap() error = %v, want %v for %s", err, ErrUnsupportedHeatmap, tt.field)
+			}
+			if sb.Len() > 0 {
+				t.Errorf("WriteHeatmap() wrote %q before reporting the error", sb.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package calendar
+
+import (
+	"io"
+	"sort"
+	"time"
+)
+
+// layoutWriter streams a complete calendar in a specific layout to w
+type layoutWriter func(w io.Writer, options Options) error
+
+// GridLayout is the default layout of one table per month with weeks as rows, rendered by the selected Renderer
+const GridLayout = "grid"
+
+// layouts maps the names accepted by Options.Layout to their writers.
+// Layouts other than the grid produce markdown directly, independent of Options.Format.
+var layouts = map[string]layoutWriter{}
+
+// LayoutNames returns the sorted names of all available layouts
+func LayoutNames() []string {
+	names := []string{GridLayout}
+	for name := range layouts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// DateRange returns the first and last day selected by the options: the whole year when no month is set,
+// the month range when an end is set, and the single month otherwise
+func (o Options) DateRange() (time.Time, time.Time) {
+	if o.Month == nil {
+		return time.Date(o.Year, time.January, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(o.Year, time.December, 31, 0, 0, 0, 0, time.UTC)
+	}
+
+	start := time.Date(o.Year, time.Month(*o.Month), 1, 0, 0, 0, 0, time.UTC)
+	if o.EndYear == nil || o.EndMonth == nil {
+		return start, start.AddDate(0, 1, -1)
+	}
+
+	return start, time.Date(*o.EndYear, time.Month(*o.EndMonth)+1, 0, 0, 0, 0, 0, time.UTC)
+}
+
+// forEachMonth calls fn with the first day of every month selected by the options, in order,
+// and stops at the first error
+func forEachMonth(options Options, fn func(firstOfMonth time.Time) error) error {
+	start, end := options.DateRange()
+	for cur := start; !cur.After(end); cur = cur.AddDate(0, 1, 0) {
+		if err := fn(cur); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
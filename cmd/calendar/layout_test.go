@@ -0,0 +1,62 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDateRange(t *testing.T) {
+	tests := []struct {
+		name          string
+		options       Options
+		expectedStart time.Time
+		expectedEnd   time.Time
+	}{
+		{
+			name:          "Whole year",
+			options:       Options{Year: 2024},
+			expectedStart: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "Single month",
+			options:       Options{Year: 2024, Month: intPtr(2)},
+			expectedStart: time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "Range across years",
+			options:       Options{Year: 2024, Month: intPtr(11), EndYear: intPtr(2025), EndMonth: intPtr(2)},
+			expectedStart: time.Date(2024, time.November, 1, 0, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2025, time.February, 28, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := tt.options.DateRange()
+			if !start.Equal(tt.expectedStart) || !end.Equal(tt.expectedEnd) {
+				t.Errorf("DateRange() = (%v, %v), want (%v, %v)", start, end, tt.expectedStart, tt.expectedEnd)
+			}
+		})
+	}
+}
+
+func TestForEachMonth(t *testing.T) {
+	options := Options{Year: 2024, Month: intPtr(11), EndYear: intPtr(2025), EndMonth: intPtr(2)}
+
+	var months []string
+	err := forEachMonth(options, func(firstOfMonth time.Time) error {
+		months = append(months, firstOfMonth.Format("2006-01"))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("forEachMonth() returned error: %v", err)
+	}
+
+	expected := "2024-11 2024-12 2025-01 2025-02"
+	if actual := strings.Join(months, " "); actual != expected {
+		t.Errorf("forEachMonth() visited %q, want %q", actual, expected)
+	}
+}
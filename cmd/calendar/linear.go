@@ -0,0 +1,157 @@
+package calendar
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+func init() {
+	layouts["linear"] = writeLinearCalendar
+}
+
+// holidayMark is appended to the cells of holidays in layouts without room for annotation text
+const holidayMark = "★"
+
+// linearColumns is the number of day columns in the weekday-aligned variant: 31 days plus up to 6 leading blanks
+const linearColumns = 37
+
+// isWeekend reports whether the weekday is a Saturday or Sunday
+func isWeekend(wd time.Weekday) bool {
+	return wd == time.Saturday || wd == time.Sunday
+}
+
+// isHoliday reports whether any of the annotations marks its day as a holiday
+func isHoliday(annotations []Annotation) bool {
+	for _, a := range annotations {
+		if a.Holiday {
+			return true
+		}
+	}
+	return false
+}
+
+// linearDayCell marks a day label as a weekend or holiday, leaving weekends blank when they are hidden
+func linearDayCell(date time.Time, label string, annotators []Annotator, showWeekends bool) string {
+	cell := label
+	if isWeekend(date.Weekday()) {
+		if !showWeekends {
+			return ""
+		}
+		cell = "**" + label + "**"
+	}
+	if isHoliday(annotate(date, annotators)) {
+		cell += " " + holidayMark
+	}
+	return cell
+}
+
+// linearColumnWeekdays returns the weekday of each column of the weekday-aligned variant,
+// leaving out weekend columns when they are hidden
+func linearColumnWeekdays(firstDayOfWeek time.Weekday, showWeekends bool) []time.Weekday {
+	var weekDays []time.Weekday
+	for i := 0; i < linearColumns; i++ {
+		wd := time.Weekday((int(firstDayOfWeek) + i) % 7)
+		if !showWeekends && isWeekend(wd) {
+			continue
+		}
+		weekDays = append(weekDays, wd)
+	}
+	return weekDays
+}
+
+// linearHeaders creates the column headers of the year planner
+func linearHeaders(options Options) []string {
+	headers := []string{"Month"}
+	if options.AlignWeekdays {
+		for _, wd := range linearColumnWeekdays(options.FirstDayOfWeek, options.ShowWeekends) {
+			headers = append(headers, wd.String()[:2])
+		}
+	} else {
+		for d := 1; d <= 31; d++ {
+			headers = append(headers, strconv.Itoa(d))
+		}
+	}
+	if options.ShowComments {
+		headers = append(headers, "Comments")
+	}
+	return headers
+}
+
+// linearRow creates the row of the year planner for a single month
+func linearRow(firstOfMonth time.Time, options Options, annotators []Annotator) []string {
+	row := []string{firstOfMonth.Month().String()}
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+
+	if options.AlignWeekdays {
+		// Start at the first column falling on the weekday of the 1st, then move forward one column per displayed day
+		offset := (int(firstOfMonth.Weekday()) - int(options.FirstDayOfWeek) + 7) % 7
+		date := firstOfMonth.AddDate(0, 0, -offset)
+		for range linearColumnWeekdays(options.FirstDayOfWeek, options.ShowWeekends) {
+			for !options.ShowWeekends && isWeekend(date.Weekday()) {
+				date = date.AddDate(0, 0, 1)
+			}
+			if date.Month() == firstOfMonth.Month() && !date.Before(firstOfMonth) {
+				row = append(row, linearDayCell(date, options.dayLabel(date, strconv.Itoa(date.Day())), annotators, options.ShowWeekends))
+			} else {
+				row = append(row, "")
+			}
+			date = date.AddDate(0, 0, 1)
+		}
+	} else {
+		for d := 1; d <= 31; d++ {
+			if d > lastDay {
+				row = append(row, "")
+				continue
+			}
+			date := firstOfMonth.AddDate(0, 0, d-1)
+			row = append(row, linearDayCell(date, options.dayLabel(date, date.Weekday().String()[:2]), annotators, options.ShowWeekends))
+		}
+	}
+
+	if options.ShowComments {
+		row = append(row, "")
+	}
+	return row
+}
+
+// writeLinearCalendar streams a year planner with one table per year, where each row is a month
+// of the selected range and each column a day of the month (or a weekday, when AlignWeekdays is set)
+func writeLinearCalendar(w io.Writer, options Options) error {
+	annotators := sortedAnnotators(options.Annotators)
+	headers := linearHeaders(options)
+
+	legend := "★: holiday"
+	if options.ShowWeekends {
+		legend = "**Bold**: weekend, " + legend
+	}
+
+	var rows [][]string
+	flush := func(year int) error {
+		if len(rows) == 0 {
+			return nil
+		}
+		_, err := fmt.Fprintf(w, "# Year Planner %d\n\n%s\n%s\n\n", year, generateTable(headers, rows, options.Justify), legend)
+		rows = rows[:0]
+		return err
+	}
+
+	start, _ := options.DateRange()
+	year := start.Year()
+	err := forEachMonth(options, func(firstOfMonth time.Time) error {
+		if firstOfMonth.Year() != year {
+			if err := flush(year); err != nil {
+				return err
+			}
+			year = firstOfMonth.Year()
+		}
+		rows = append(rows, linearRow(firstOfMonth, options, annotators))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return flush(year)
+}
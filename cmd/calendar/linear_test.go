@@ -0,0 +1,93 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLinearRow(t *testing.T) {
+	holidays := []Annotator{Holidays(map[string]string{"2025-02-03": "", "2025-02-08": "Festival"})}
+	february := time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		modify   func(o *Options)
+		expected []string
+	}{
+		{
+			name:   "Day columns",
+			modify: func(o *Options) {},
+			expected: []string{"February",
+				"**Sa**", "**Su**", "Mo ★", "Tu", "We", "Th", "Fr", "**Sa** ★", "**Su**", "Mo", "Tu", "We", "Th", "Fr",
+				"**Sa**", "**Su**", "Mo", "Tu", "We", "Th", "Fr", "**Sa**", "**Su**", "Mo", "Tu", "We", "Th", "Fr",
+				"", "", ""},
+		},
+		{
+			name:   "Day columns without weekends",
+			modify: func(o *Options) { o.ShowWeekends = false },
+			expected: []string{"February",
+				"", "", "Mo ★", "Tu", "We", "Th", "Fr", "", "", "Mo", "Tu", "We", "Th", "Fr",
+				"", "", "Mo", "Tu", "We", "Th", "Fr", "", "", "Mo", "Tu", "We", "Th", "Fr",
+				"", "", ""},
+		},
+		{
+			name: "Weekday aligned, Sunday first",
+			modify: func(o *Options) {
+				o.AlignWeekdays = true
+				o.FirstDayOfWeek = time.Sunday
+			},
+			expected: append([]string{"February",
+				"", "", "", "", "", "", "**1**",
+				"**2**", "3 ★", "4", "5", "6", "7", "**8** ★",
+				"**9**", "10", "11", "12", "13", "14", "**15**",
+				"**16**", "17", "18", "19", "20", "21", "**22**",
+				"**23**", "24", "25", "26", "27", "28"}, make([]string, 3)...),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := NewOptions()
+			options.ShowComments = false
+			tt.modify(&options)
+
+			actual := linearRow(february, options, holidays)
+			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+				t.Errorf("linearRow() mismatch (-want +got):\n%s", diff)
+			}
+			if len(actual) != len(linearHeaders(options)) {
+				t.Errorf("linearRow() has %d cells, headers have %d", len(actual), len(linearHeaders(options)))
+			}
+		})
+	}
+}
+
+func TestWriteLinearCalendar(t *testing.T) {
+	options := NewOptions()
+	options.Year = 2025
+	options.Month = intPtr(11)
+	options.EndYear = intPtr(2026)
+	options.EndMonth = intPtr(1)
+	options.Layout = "linear"
+
+	output, err := PrintCalendar(options)
+	if err != nil {
+		t.Fatalf("PrintCalendar() returned error: %v", err)
+	}
+
+	if !strings.HasPrefix(output, "# Year Planner 2025\n\n| Month    | 1 ") {
+		t.Errorf("PrintCalendar() output does not start with the 2025 planner:\n%s", output)
+	}
+	if !strings.Contains(output, "# Year Planner 2026\n") {
+		t.Errorf("PrintCalendar() output has no 2026 planner:\n%s", output)
+	}
+
+	for _, month := range []string{"| November ", "| December ", "| January "} {
+		if strings.Count(output, month) != 1 {
+			t.Errorf("PrintCalendar() output should contain %q once:\n%s", month, output)
+		}
+	}
+}
@@ -0,0 +1,328 @@
+package calendar
+
+import (
+	"fmt"
+	"github.com/andre-a-alves/mdcal/cmd/utils"
+	"strings"
+	"time"
+)
+
+// MarkdownRenderer renders months as markdown tables
+type MarkdownRenderer struct{}
+
+func init() {
+	RegisterRenderer("markdown", MarkdownRenderer{})
+}
+
+// RenderMonth renders the month as a markdown heading followed by a table
+func (MarkdownRenderer) RenderMonth(month Month, options Options) string {
+	var sb strings.Builder
+
+	// Add calendar header
+	sb.WriteString(generateCalendarHeader(month.Year, month.Month))
+
+	if options.Transpose {
+		sb.WriteString(generateTransposedTable(month, options))
+		return sb.String()
+	}
+
+	// Prepare column headers and widths
+	dayShortNames, dayFullNames := weekdayNames(month.Weekdays)
+	columnHeaders, columnWidths := prepareColumnHeaders(dayShortNames, dayFullNames, options.UseShortDayNames, options.ShowCalendarWeek,
+		options.ShowComments, options.Justify)
+
+	// Widen the columns to fit their cells, such as day links and annotations
+	for _, week := range month.Weeks {
+		columnWidths = fitColumnWidths(columnWidths, weekRowCells(week, options.ShowCalendarWeek, options.ShowComments))
+	}
+
+	// Generate table header
+	sb.WriteString(generateTableHeader(columnHeaders, columnWidths, options.Justify))
+
+	// Generate each week row
+	for _, week := range month.Weeks {
+		sb.WriteString(generateWeekRow(week, columnWidths, options.ShowCalendarWeek, options.ShowComments))
+	}
+
+	return sb.String()
+}
+
+// RenderMonths renders consecutive months side by side in a single table. Each month block starts with a
+// column headed by the month name that holds its week numbers, and rows are aligned by week of the month.
+func (MarkdownRenderer) RenderMonths(months []Month, options Options) string {
+	var sb strings.Builder
+
+	sb.WriteString(generateRangeHeader(months[0], months[len(months)-1]))
+
+	var columnHeaders []string
+	rowCount := 0
+	for _, month := range months {
+		dayShortNames, dayFullNames := weekdayNames(month.Weekdays)
+		dayNames := dayFullNames
+		if options.UseShortDayNames {
+			dayNames = dayShortNames
+		}
+		columnHeaders = append(columnHeaders, fmt.Sprintf("%s %d", month.Month, month.Year))
+		columnHeaders = append(columnHeaders, dayNames...)
+		rowCount = max(rowCount, len(month.Weeks))
+	}
+	if options.ShowComments {
+		columnHeaders = append(columnHeaders, "Comments")
+	}
+
+	rows := make([][]string, rowCount)
+	for i := range rows {
+		for _, month := range months {
+			if i >= len(month.Weeks) {
+				// Pad months with fewer weeks
+				rows[i] = append(rows[i], make([]string, len(month.Weekdays)+1)...)
+				continue
+			}
+			week := month.Weeks[i]
+			if options.ShowCalendarWeek {
+				rows[i] = append(rows[i], "_"+week.Label+"_")
+			} else {
+				rows[i] = append(rows[i], "")
+			}
+			rows[i] = append(rows[i], dayCells(week)...)
+		}
+		if options.ShowComments {
+			rows[i] = append(rows[i], "")
+		}
+	}
+
+	sb.WriteString(generateTable(columnHeaders, rows, options.Justify))
+
+	return sb.String()
+}
+
+// generateTransposedTable creates a month table with the weekdays as rows and the weeks as columns,
+// headed by their week numbers, with the comments as a final row
+func generateTransposedTable(month Month, options Options) string {
+	columnHeaders := []string{""}
+	if options.ShowCalendarWeek {
+		columnHeaders[0] = "CW"
+	}
+	for _, week := range month.Weeks {
+		if options.ShowCalendarWeek {
+			columnHeaders = append(columnHeaders, "_"+week.Label+"_")
+		} else {
+			columnHeaders = append(columnHeaders, "")
+		}
+	}
+
+	dayShortNames, dayFullNames := weekdayNames(month.Weekdays)
+	dayNames := dayFullNames
+	if options.UseShortDayNames {
+		dayNames = dayShortNames
+	}
+
+	weekCells := make([][]string, len(month.Weeks))
+	for i, week := range month.Weeks {
+		weekCells[i] = dayCells(week)
+	}
+
+	var rows [][]string
+	for i, name := range dayNames {
+		row := []string{"**" + name + "**"}
+		for _, cells := range weekCells {
+			row = append(row, cells[i])
+		}
+		rows = append(rows, row)
+	}
+	if options.ShowComments {
+		rows = append(rows, append([]string{"**Comments**"}, make([]string, len(month.Weeks))...))
+	}
+
+	return generateTable(columnHeaders, rows, options.Justify)
+}
+
+// generateRangeHeader creates the header for a calendar spanning from the first to the last month
+func generateRangeHeader(first Month, last Month) string {
+	switch {
+	case first.Year == last.Year && first.Month == last.Month:
+		return generateCalendarHeader(first.Year, first.Month)
+	case first.Year == last.Year:
+		return fmt.Sprintf("# %s – %s %d\n\n", first.Month, last.Month, last.Year)
+	default:
+		return fmt.Sprintf("# %s %d – %s %d\n\n", first.Month, first.Year, last.Month, last.Year)
+	}
+}
+
+// generateCalendarHeader creates the header for the calendar with month and year
+func generateCalendarHeader(year int, month time.Month) string {
+	return fmt.Sprintf("# %s %d\n\n", month.String(), year)
+}
+
+// weekdayNames returns the short and full names of the given weekdays
+func weekdayNames(weekDays []time.Weekday) ([]string, []string) {
+	var dayShortNames, dayFullNames []string
+	for _, wd := range weekDays {
+		dayShortNames = append(dayShortNames, wd.String()[:3])
+		dayFullNames = append(dayFullNames, wd.String())
+	}
+	return dayShortNames, dayFullNames
+}
+
+// prepareColumnHeaders creates the column headers and their widths
+func prepareColumnHeaders(dayShortNames []string, dayFullNames []string, useShortDayNames bool, showCalendarWeek bool, showComments bool, justify string) ([]string, []int) {
+	var columnHeaders []string
+	var columnWidths []int
+
+	if showCalendarWeek {
+		if strings.ToLower(justify) == "center" {
+			columnHeaders = append(columnHeaders, "CW   ")
+			columnWidths = append(columnWidths, len("CW   "))
+		} else {
+			columnHeaders = append(columnHeaders, "CW  ")
+			columnWidths = append(columnWidths, len("CW  "))
+		}
+	}
+
+	// Use short day names if the option is set, otherwise use full day names
+	dayNames := dayFullNames
+	if useShortDayNames {
+		dayNames = dayShortNames
+	}
+
+	for _, d := range dayNames {
+		columnHeaders = append(columnHeaders, d)
+		columnWidths = append(columnWidths, len(d))
+	}
+
+	if showComments {
+		columnHeaders = append(columnHeaders, "Comments")
+		columnWidths = append(columnWidths, len("Comments"))
+	}
+
+	return columnHeaders, columnWidths
+}
+
+// generateTableHeader creates the header row and separator row for the Markdown table
+func generateTableHeader(columnHeaders []string, columnWidths []int, justify string) string {
+	var sb strings.Builder
+
+	// header row
+	sb.WriteString("|")
+	for i, h := range columnHeaders {
+		w := columnWidths[i]
+		sb.WriteString(" " + utils.PadRight(h, w) + " |")
+	}
+	sb.WriteString("\n")
+
+	// separator row
+	sb.WriteString("|")
+	for _, w := range columnWidths {
+		sep := utils.SeparatorCell(w, justify)
+		sb.WriteString(" " + sep + " |")
+	}
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// generateWeekRow creates a single week row for the calendar
+func generateWeekRow(week Week, columnWidths []int, showCalendarWeek bool, showComments bool) string {
+	return generateTableRow(weekRowCells(week, showCalendarWeek, showComments), columnWidths)
+}
+
+// weekRowCells creates the cells of a week row: the week number, the days and the comments
+func weekRowCells(week Week, showCalendarWeek bool, showComments bool) []string {
+	var cells []string
+
+	if showCalendarWeek {
+		cells = append(cells, "_"+week.Label+"_")
+	}
+
+	cells = append(cells, dayCells(week)...)
+
+	if showComments {
+		cells = append(cells, "")
+	}
+
+	return cells
+}
+
+// dayCells creates the cells for the days of a week, leaving days outside the month blank
+func dayCells(week Week) []string {
+	var cells []string
+	for _, day := range week.Days {
+		if day.InMonth {
+			cells = append(cells, day.Label+formatAnnotations(day.Annotations))
+		} else {
+			cells = append(cells, "")
+		}
+	}
+	return cells
+}
+
+// generateTableRow creates a single row of a markdown table
+func generateTableRow(cells []string, columnWidths []int) string {
+	var sb strings.Builder
+
+	sb.WriteString("|")
+	for i, cell := range cells {
+		w := columnWidths[i]
+		sb.WriteString(" " + utils.PadRight(cell, w) + " |")
+	}
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// fitColumnWidths widens the column widths to fit the display width of the cells of a row
+func fitColumnWidths(columnWidths []int, cells []string) []int {
+	for i, cell := range cells {
+		columnWidths[i] = max(columnWidths[i], utils.DisplayWidth(cell))
+	}
+	return columnWidths
+}
+
+// generateTable creates a complete markdown table, sizing each column to fit the display width of its header and its widest cell
+func generateTable(columnHeaders []string, rows [][]string, justify string) string {
+	var sb strings.Builder
+
+	columnWidths := make([]int, len(columnHeaders))
+	for i, h := range columnHeaders {
+		columnWidths[i] = utils.DisplayWidth(h)
+	}
+	for _, row := range rows {
+		columnWidths = fitColumnWidths(columnWidths, row)
+	}
+
+	sb.WriteString(generateTableHeader(columnHeaders, columnWidths, justify))
+	for _, row := range rows {
+		sb.WriteString(generateTableRow(row, columnWidths))
+	}
+
+	return sb.String()
+}
+
+// escapeCell escapes characters that would break a markdown table cell
+func escapeCell(text string) string {
+	text = strings.ReplaceAll(text, "|", "\\|")
+	return strings.ReplaceAll(text, "\n", " ")
+}
+
+// appendWeekLabel appends an overlay such as a sprint name to a week label, escaped as the renderers write week
+// labels as they are
+func appendWeekLabel(text string, overlay string) string {
+	return text + " · " + escapeCell(overlay)
+}
+
+// formatAnnotation renders a single annotation, linking it when it has a link target
+func formatAnnotation(a Annotation) string {
+	if a.Link != "" {
+		return "[" + escapeCell(a.Text) + "](" + a.Link + ")"
+	}
+	return escapeCell(a.Text)
+}
+
+// formatAnnotations renders annotations as line breaks inside a table cell, linking those that have a link target
+func formatAnnotations(annotations []Annotation) string {
+	var sb strings.Builder
+	for _, a := range annotations {
+		sb.WriteString("<br>" + formatAnnotation(a))
+	}
+	return sb.String()
+}
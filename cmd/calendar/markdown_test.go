@@ -0,0 +1,392 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGenerateCalendarHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		year     int
+		month    time.Month
+		expected string
+	}{
+		{
+			name:     "January 2023",
+			year:     2023,
+			month:    time.January,
+			expected: "# January 2023\n\n",
+		},
+		{
+			name:     "December 2025",
+			year:     2025,
+			month:    time.December,
+			expected: "# December 2025\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := generateCalendarHeader(tt.year, tt.month)
+			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+				t.Errorf("generateCalendarHeader(%d, %v) mismatch (-want +got):\n%s", tt.year, tt.month, diff)
+			}
+		})
+	}
+}
+
+func TestPrepareColumnHeaders(t *testing.T) {
+	tests := []struct {
+		name             string
+		dayShortNames    []string
+		dayFullNames     []string
+		useShortDayNames bool
+		showCalendarWeek bool
+		showComments     bool
+		justify          string
+		expectedHeaders  []string
+		expectedWidths   []int
+	}{
+		{
+			name:             "Short names, with week numbers and comments",
+			dayShortNames:    []string{"Mon", "Tue", "Wed", "Thu", "Fri"},
+			dayFullNames:     []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"},
+			useShortDayNames: true,
+			showCalendarWeek: true,
+			showComments:     true,
+			justify:          "left",
+			expectedHeaders:  []string{"CW  ", "Mon", "Tue", "Wed", "Thu", "Fri", "Comments"},
+			expectedWidths:   []int{4, 3, 3, 3, 3, 3, 8},
+		},
+		{
+			name:             "Full names, with week numbers and comments",
+			dayShortNames:    []string{"Mon", "Tue", "Wed", "Thu", "Fri"},
+			dayFullNames:     []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"},
+			useShortDayNames: false,
+			showCalendarWeek: true,
+			showComments:     true,
+			justify:          "left",
+			expectedHeaders:  []string{"CW  ", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Comments"},
+			expectedWidths:   []int{4, 6, 7, 9, 8, 6, 8},
+		},
+		{
+			name:             "Short names, no week numbers, with comments",
+			dayShortNames:    []string{"Mon", "Tue", "Wed", "Thu", "Fri"},
+			dayFullNames:     []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"},
+			useShortDayNames: true,
+			showCalendarWeek: false,
+			showComments:     true,
+			justify:          "left",
+			expectedHeaders:  []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Comments"},
+			expectedWidths:   []int{3, 3, 3, 3, 3, 8},
+		},
+		{
+			name:             "Short names, with week numbers, no comments",
+			dayShortNames:    []string{"Mon", "Tue", "Wed", "Thu", "Fri"},
+			dayFullNames:     []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"},
+			useShortDayNames: true,
+			showCalendarWeek: true,
+			showComments:     false,
+			justify:          "left",
+			expectedHeaders:  []string{"CW  ", "Mon", "Tue", "Wed", "Thu", "Fri"},
+			expectedWidths:   []int{4, 3, 3, 3, 3, 3},
+		},
+		{
+			name:             "Center justify",
+			dayShortNames:    []string{"Mon", "Tue", "Wed", "Thu", "Fri"},
+			dayFullNames:     []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"},
+			useShortDayNames: true,
+			showCalendarWeek: true,
+			showComments:     true,
+			justify:          "center",
+			expectedHeaders:  []string{"CW   ", "Mon", "Tue", "Wed", "Thu", "Fri", "Comments"},
+			expectedWidths:   []int{5, 3, 3, 3, 3, 3, 8},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers, widths := prepareColumnHeaders(tt.dayShortNames, tt.dayFullNames, tt.useShortDayNames,
+				tt.showCalendarWeek, tt.showComments, tt.justify)
+
+			if diff := cmp.Diff(tt.expectedHeaders, headers); diff != "" {
+				t.Errorf("prepareColumnHeaders() headers mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.expectedWidths, widths); diff != "" {
+				t.Errorf("prepareColumnHeaders() widths mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateTableHeader(t *testing.T) {
+	tests := []struct {
+		name          string
+		columnHeaders []string
+		columnWidths  []int
+		justify       string
+		expected      string
+	}{
+		{
+			name:          "Left justify",
+			columnHeaders: []string{"CW", "Mon", "Tue", "Wed"},
+			columnWidths:  []int{2, 3, 3, 3},
+			justify:       "left",
+			expected:      "| CW | Mon | Tue | Wed |\n| :- | :-- | :-- | :-- |\n",
+		},
+		{
+			name:          "Center justify",
+			columnHeaders: []string{"CW", "Mon", "Tue", "Wed"},
+			columnWidths:  []int{2, 3, 3, 3},
+			justify:       "center",
+			expected:      "| CW | Mon | Tue | Wed |\n| :-: | :-: | :-: | :-: |\n",
+		},
+		{
+			name:          "Right justify",
+			columnHeaders: []string{"CW", "Mon", "Tue", "Wed"},
+			columnWidths:  []int{2, 3, 3, 3},
+			justify:       "right",
+			expected:      "| CW | Mon | Tue | Wed |\n| -: | --: | --: | --: |\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := generateTableHeader(tt.columnHeaders, tt.columnWidths, tt.justify)
+			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+				t.Errorf("generateTableHeader() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateWeekRow(t *testing.T) {
+	tests := []struct {
+		name             string
+		week             Week
+		columnWidths     []int
+		showCalendarWeek bool
+		showComments     bool
+		expected         string
+	}{
+		{
+			name: "First week of January 2023, Monday first",
+			week: buildWeek(time.Date(2022, time.December, 26, 0, 0, 0, 0, time.UTC), time.January,
+				[]time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}, NewOptions(), nil),
+			columnWidths:     []int{4, 3, 3, 3, 3, 3, 8}, // Need 7 elements: 1 for week number, 5 for weekdays, 1 for comments
+			showCalendarWeek: true,
+			showComments:     true,
+			expected:         "| _52_ |     |     |     |     |     |          |\n",
+		},
+		{
+			name: "Second week of January 2023, no week numbers or comments",
+			week: buildWeek(time.Date(2023, time.January, 2, 0, 0, 0, 0, time.UTC), time.January,
+				[]time.Weekday{time.Monday, time.Tuesday, time.Wednesday}, NewOptions(), nil),
+			columnWidths:     []int{3, 3, 3},
+			showCalendarWeek: false,
+			showComments:     false,
+			expected:         "| 2   | 3   | 4   |\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := generateWeekRow(tt.week, tt.columnWidths, tt.showCalendarWeek, tt.showComments)
+			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+				t.Errorf("generateWeekRow() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMarkdownRendererRenderMonth(t *testing.T) {
+	options := NewOptions()
+	options.Year = 2025
+	options.Month = intPtr(2)
+	options.UseShortDayNames = true
+
+	expected := "# February 2025\n\n" +
+		"| CW   | Mon | Tue | Wed | Thu | Fri | Sat | Sun | Comments |\n" +
+		"| :--- | :-- | :-- | :-- | :-- | :-- | :-- | :-- | :------- |\n" +
+		"| _5_  |     |     |     |     |     | 1   | 2   |          |\n" +
+		"| _6_  | 3   | 4   | 5   | 6   | 7   | 8   | 9   |          |\n" +
+		"| _7_  | 10  | 11  | 12  | 13  | 14  | 15  | 16  |          |\n" +
+		"| _8_  | 17  | 18  | 19  | 20  | 21  | 22  | 23  |          |\n" +
+		"| _9_  | 24  | 25  | 26  | 27  | 28  |     |     |          |\n"
+
+	actual := MarkdownRenderer{}.RenderMonth(BuildMonth(options), options)
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("RenderMonth() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestMarkdownRendererRenderMonthLabels(t *testing.T) {
+	options := NewOptions()
+	options.Year = 2025
+	options.Month = intPtr(2)
+	options.UseShortDayNames = true
+	options.ShowComments = false
+	options.ShowWeekends = false
+	options.DayLabel = func(date time.Time, text string) string {
+		if date.Day() == 14 {
+			return "[" + text + "](14.md)"
+		}
+		return text
+	}
+	options.WeekLabel = func(date time.Time, text string) string {
+		return "W" + text
+	}
+
+	expected := "# February 2025\n\n" +
+		"| CW   | Mon | Tue | Wed | Thu | Fri         |\n" +
+		"| :--- | :-- | :-- | :-- | :-- | :---------- |\n" +
+		"| _W5_ |     |     |     |     |             |\n" +
+		"| _W6_ | 3   | 4   | 5   | 6   | 7           |\n" +
+		"| _W7_ | 10  | 11  | 12  | 13  | [14](14.md) |\n" +
+		"| _W8_ | 17  | 18  | 19  | 20  | 21          |\n" +
+		"| _W9_ | 24  | 25  | 26  | 27  | 28          |\n"
+
+	actual := MarkdownRenderer{}.RenderMonth(BuildMonth(options), options)
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("RenderMonth() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWeekdayNames(t *testing.T) {
+	shortNames, fullNames := weekdayNames([]time.Weekday{time.Sunday, time.Monday, time.Saturday})
+
+	if diff := cmp.Diff([]string{"Sun", "Mon", "Sat"}, shortNames); diff != "" {
+		t.Errorf("weekdayNames() short names mismatch (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]string{"Sunday", "Monday", "Saturday"}, fullNames); diff != "" {
+		t.Errorf("weekdayNames() full names mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFormatAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations []Annotation
+		expected    string
+	}{
+		{
+			name:        "No annotations",
+			annotations: nil,
+			expected:    "",
+		},
+		{
+			name:        "Plain and linked annotations",
+			annotations: []Annotation{{Text: "On-call: Alice"}, {Text: "Release", Link: "https://example.com/r/1"}},
+			expected:    "<br>On-call: Alice<br>[Release](https://example.com/r/1)",
+		},
+		{
+			name:        "Pipes and newlines are escaped",
+			annotations: []Annotation{{Text: "a|b\nc"}},
+			expected:    "<br>a\\|b c",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := formatAnnotations(tt.annotations)
+			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+				t.Errorf("formatAnnotations() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMarkdownRendererRenderMonths(t *testing.T) {
+	options := NewOptions()
+	options.UseShortDayNames = true
+	options.ShowWeekends = false
+	options.ShowComments = false
+
+	var months []Month
+	for _, m := range []int{12, 1} {
+		optionsCopy := options
+		optionsCopy.Year = 2025
+		if m == 1 {
+			optionsCopy.Year = 2026
+		}
+		optionsCopy.Month = intPtr(m)
+		months = append(months, BuildMonth(optionsCopy))
+	}
+
+	expected := "# December 2025 – January 2026\n\n" +
+		"| December 2025 | Mon | Tue | Wed | Thu | Fri | January 2026 | Mon | Tue | Wed | Thu | Fri |\n" +
+		"| :------------ | :-- | :-- | :-- | :-- | :-- | :----------- | :-- | :-- | :-- | :-- | :-- |\n" +
+		"| _49_          | 1   | 2   | 3   | 4   | 5   | _1_          |     |     |     | 1   | 2   |\n" +
+		"| _50_          | 8   | 9   | 10  | 11  | 12  | _2_          | 5   | 6   | 7   | 8   | 9   |\n" +
+		"| _51_          | 15  | 16  | 17  | 18  | 19  | _3_          | 12  | 13  | 14  | 15  | 16  |\n" +
+		"| _52_          | 22  | 23  | 24  | 25  | 26  | _4_          | 19  | 20  | 21  | 22  | 23  |\n" +
+		"| _1_           | 29  | 30  | 31  |     |     | _5_          | 26  | 27  | 28  | 29  | 30  |\n"
+
+	actual := MarkdownRenderer{}.RenderMonths(months, options)
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("RenderMonths() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateRangeHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		first    Month
+		last     Month
+		expected string
+	}{
+		{
+			name:     "Single month",
+			first:    Month{Year: 2025, Month: time.March},
+			last:     Month{Year: 2025, Month: time.March},
+			expected: "# March 2025\n\n",
+		},
+		{
+			name:     "Same year",
+			first:    Month{Year: 2025, Month: time.January},
+			last:     Month{Year: 2025, Month: time.March},
+			expected: "# January – March 2025\n\n",
+		},
+		{
+			name:     "Across years",
+			first:    Month{Year: 2025, Month: time.December},
+			last:     Month{Year: 2026, Month: time.February},
+			expected: "# December 2025 – February 2026\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.expected, generateRangeHeader(tt.first, tt.last)); diff != "" {
+				t.Errorf("generateRangeHeader() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateTransposedTable(t *testing.T) {
+	options := NewOptions()
+	options.Year = 2025
+	options.Month = intPtr(2)
+	options.UseShortDayNames = true
+	options.ShowWeekends = false
+
+	expected := "| CW           | _5_ | _6_ | _7_ | _8_ | _9_ |\n" +
+		"| :----------- | :-- | :-- | :-- | :-- | :-- |\n" +
+		"| **Mon**      |     | 3   | 10  | 17  | 24  |\n" +
+		"| **Tue**      |     | 4   | 11  | 18  | 25  |\n" +
+		"| **Wed**      |     | 5   | 12  | 19  | 26  |\n" +
+		"| **Thu**      |     | 6   | 13  | 20  | 27  |\n" +
+		"| **Fri**      |     | 7   | 14  | 21  | 28  |\n" +
+		"| **Comments** |     |     |     |     |     |\n"
+
+	actual := generateTransposedTable(BuildMonth(options), options)
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("generateTransposedTable() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,71 @@
+package calendar
+
+import (
+	"strconv"
+	"time"
+)
+
+// Day represents a single day cell of a calendar week
+type Day struct {
+	Date        time.Time
+	InMonth     bool         // false for days that pad the first and last week of the month
+	Annotations []Annotation // collected from the annotators registered on Options, for in-month days only
+	Label       string       // text shown for the day: its number, formatted by Options.DayLabel when set
+}
+
+// Week represents a single row of a calendar month
+type Week struct {
+	Start  time.Time // first day of the week, based on FirstDayOfWeek
+	Number int       // ISO week number of Start
+	Label  string    // text shown for the week number, formatted by Options.WeekLabel when set
+	Days   []Day     // one day per displayed weekday, in column order
+}
+
+// Month represents the computed layout of a calendar month, independent of any output syntax
+type Month struct {
+	Year     int
+	Month    time.Month
+	Weekdays []time.Weekday // displayed weekdays, in column order
+	Weeks    []Week
+}
+
+// buildWeek computes a single week starting at cur for the given month, labelled as set in options
+func buildWeek(cur time.Time, month time.Month, weekDays []time.Weekday, options Options, annotators []Annotator) Week {
+	_, number := cur.ISOWeek()
+	week := Week{Start: cur, Number: number, Label: options.weekLabel(cur)}
+
+	for _, wd := range weekDays {
+		delta := (int(wd) - int(options.FirstDayOfWeek) + 7) % 7
+		cd := cur.AddDate(0, 0, delta)
+		day := Day{Date: cd, InMonth: cd.Month() == month, Label: strconv.Itoa(cd.Day())}
+		if day.InMonth {
+			day.Annotations = annotate(cd, annotators)
+			day.Label = options.dayLabel(cd, day.Label)
+		}
+		week.Days = append(week.Days, day)
+	}
+
+	return week
+}
+
+// BuildMonth computes the month model for the year and month selected in options
+func BuildMonth(options Options) Month {
+	month := time.Month(*options.Month)
+
+	// Get the displayed weekdays
+	dayShortNames, _ := getWeekdayNames(options.FirstDayOfWeek, options.ShowWeekends)
+	weekDays := convertToWeekdays(dayShortNames)
+
+	// Calculate month boundaries
+	_, lastOfMonth, weekStart := calculateMonthBoundaries(options.Year, month, options.FirstDayOfWeek)
+
+	// Run annotators in priority order
+	annotators := sortedAnnotators(options.Annotators)
+
+	result := Month{Year: options.Year, Month: month, Weekdays: weekDays}
+	for cur := weekStart; !cur.After(lastOfMonth); cur = cur.AddDate(0, 0, 7) {
+		result.Weeks = append(result.Weeks, buildWeek(cur, month, weekDays, options, annotators))
+	}
+
+	return result
+}
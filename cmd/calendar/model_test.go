@@ -0,0 +1,70 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBuildMonth(t *testing.T) {
+	options := NewOptions()
+	options.Year = 2024
+	options.Month = intPtr(2)
+	options.FirstDayOfWeek = time.Sunday
+	options.ShowWeekends = false
+
+	month := BuildMonth(options)
+
+	if month.Year != 2024 || month.Month != time.February {
+		t.Fatalf("BuildMonth() = %d %v, want 2024 February", month.Year, month.Month)
+	}
+
+	expectedWeekdays := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	if diff := cmp.Diff(expectedWeekdays, month.Weekdays); diff != "" {
+		t.Errorf("BuildMonth() weekdays mismatch (-want +got):\n%s", diff)
+	}
+
+	if len(month.Weeks) != 5 {
+		t.Fatalf("BuildMonth() returned %d weeks, want 5", len(month.Weeks))
+	}
+
+	first := month.Weeks[0]
+	if !first.Start.Equal(time.Date(2024, time.January, 28, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("BuildMonth() first week start = %v, want 2024-01-28", first.Start)
+	}
+	if first.Number != 4 {
+		t.Errorf("BuildMonth() first week number = %d, want 4", first.Number)
+	}
+
+	var inMonth []int
+	for _, day := range first.Days {
+		if day.InMonth {
+			inMonth = append(inMonth, day.Date.Day())
+		}
+	}
+	if diff := cmp.Diff([]int{1, 2}, inMonth); diff != "" {
+		t.Errorf("BuildMonth() first week days mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBuildMonthDayLabel(t *testing.T) {
+	options := NewOptions()
+	options.Year = 2025
+	options.Month = intPtr(3)
+	options.DayLabel = func(date time.Time, text string) string {
+		return "[[" + date.Format(time.DateOnly) + "|" + text + "]]"
+	}
+
+	month := BuildMonth(options)
+
+	var labels []string
+	for _, day := range month.Weeks[0].Days {
+		labels = append(labels, day.Label)
+	}
+	// Padding days keep their number
+	expected := []string{"24", "25", "26", "27", "28", "[[2025-03-01|1]]", "[[2025-03-02|2]]"}
+	if diff := cmp.Diff(expected, labels); diff != "" {
+		t.Errorf("BuildMonth() labels mismatch (-want +got):\n%s", diff)
+	}
+}
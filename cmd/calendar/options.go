@@ -1,6 +1,11 @@
 package calendar
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
 
 // Options represents the configuration for generating a calendar
 type Options struct {
@@ -14,6 +19,22 @@ type Options struct {
 	ShowComments     bool
 	UseShortDayNames bool // Use short day names (Mon, Tue, etc.) instead of full names
 	Justify          string
+	Format           string           // Name of the registered renderer used for output
+	Layout           string           // Calendar layout, see LayoutNames
+	AlignWeekdays    bool             // Align the linear layout by weekday instead of by day of the month
+	Columns          int              // Number of months placed side by side in the grid layout
+	Transpose        bool             // Show weekdays as rows and weeks as columns in the grid layout
+	Checkboxes       bool             // Add checkboxes to the agenda notes and habits cells
+	DayStart         time.Duration    // Start of the first time slot of the week layout, as an offset from midnight
+	DayEnd           time.Duration    // End of the last time slot of the week layout, as an offset from midnight
+	Slot             time.Duration    // Length of each time slot of the week layout
+	Habits           []string         // Habits tracked in the columns of the habits layout
+	Annotators       []AnnotatorEntry // Annotators run for every day before rendering, see AddAnnotator
+	// DayLabel formats the text shown for a day, such as its number in the grid or its date in the agenda,
+	// for example to turn it into a link. Nil shows the text as is.
+	DayLabel func(date time.Time, text string) string
+	// WeekLabel formats the week number shown for a week, given a day of that ISO week. Nil shows the number as is.
+	WeekLabel func(date time.Time, text string) string
 }
 
 // NewOptions creates a new Options instance with default values
@@ -29,5 +50,104 @@ func NewOptions() Options {
 		ShowComments:     true,
 		UseShortDayNames: false,
 		Justify:          "left",
+		Format:           "markdown",
+		Layout:           GridLayout,
+		Columns:          1,
+		DayStart:         8 * time.Hour,
+		DayEnd:           18 * time.Hour,
+		Slot:             30 * time.Minute,
 	}
 }
+
+// Validate checks the options for values that cannot produce a calendar.
+// All problems are reported together; each is a *ValidationError wrapping one of the Err* sentinel errors,
+// so callers can test for a specific problem with errors.Is.
+func (o Options) Validate() error {
+	var errs []error
+
+	if o.Year < 1 || o.Year > 9999 {
+		errs = append(errs, &ValidationError{Field: "Year", Value: fmt.Sprint(o.Year), Err: ErrInvalidYear})
+	}
+	if o.Month != nil && (*o.Month < 1 || *o.Month > 12) {
+		errs = append(errs, &ValidationError{Field: "Month", Value: fmt.Sprint(*o.Month), Err: ErrInvalidMonth})
+	}
+
+	if (o.EndYear == nil) != (o.EndMonth == nil) {
+		errs = append(errs, &ValidationError{Field: "EndMonth", Value: formatOptionalRange(o.EndYear, o.EndMonth), Err: ErrIncompleteRange})
+	} else if o.EndYear != nil {
+		rangeValid := true
+		if *o.EndYear < 1 || *o.EndYear > 9999 {
+			errs = append(errs, &ValidationError{Field: "EndYear", Value: fmt.Sprint(*o.EndYear), Err: ErrInvalidYear})
+			rangeValid = false
+		}
+		if *o.EndMonth < 1 || *o.EndMonth > 12 {
+			errs = append(errs, &ValidationError{Field: "EndMonth", Value: fmt.Sprint(*o.EndMonth), Err: ErrInvalidMonth})
+			rangeValid = false
+		}
+		if rangeValid {
+			if err := validateDateRange(o); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if o.FirstDayOfWeek < time.Sunday || o.FirstDayOfWeek > time.Saturday {
+		errs = append(errs, &ValidationError{Field: "FirstDayOfWeek", Value: fmt.Sprint(int(o.FirstDayOfWeek)), Err: ErrInvalidWeekday})
+	}
+	if err := validateJustify(o.Justify); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateFormat(o.Format); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateLayout(o.Layout, o.Format); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateColumns(o.Columns, o.Layout, o.Format); err != nil {
+		errs = append(errs, err)
+	}
+	if o.Transpose && (o.Layout != GridLayout || o.Columns > 1) {
+		errs = append(errs, &ValidationError{Field: "Transpose", Value: fmt.Sprint(o.Transpose), Err: ErrUnsupportedTranspose})
+	}
+	if o.Layout == "week" {
+		if o.DayStart < 0 || o.DayEnd > 24*time.Hour || o.DayStart >= o.DayEnd {
+			errs = append(errs, &ValidationError{Field: "DayEnd", Value: formatClock(o.DayStart) + "-" + formatClock(o.DayEnd), Err: ErrInvalidHours})
+		}
+		if o.Slot <= 0 {
+			errs = append(errs, &ValidationError{Field: "Slot", Value: o.Slot.String(), Err: ErrInvalidSlot})
+		}
+	}
+	if o.Layout == "habits" && len(o.Habits) == 0 {
+		errs = append(errs, &ValidationError{Field: "Habits", Value: "", Err: ErrNoHabits})
+	}
+
+	return errors.Join(errs...)
+}
+
+// formatOptionalRange formats a partially specified end of range for error messages
+func formatOptionalRange(year *int, month *int) string {
+	if year != nil {
+		return fmt.Sprintf("%d-?", *year)
+	}
+	if month != nil {
+		return fmt.Sprintf("?-%02d", *month)
+	}
+	return ""
+}
+
+// dayLabel returns the text shown for the date, formatted by DayLabel when it is set
+func (o Options) dayLabel(date time.Time, text string) string {
+	if o.DayLabel == nil {
+		return text
+	}
+	return o.DayLabel(date, text)
+}
+
+// weekLabel returns the week number shown for the ISO week of the date, formatted by WeekLabel when it is set
+func (o Options) weekLabel(date time.Time) string {
+	_, number := date.ISOWeek()
+	if o.WeekLabel == nil {
+		return strconv.Itoa(number)
+	}
+	return o.WeekLabel(date, strconv.Itoa(number))
+}
@@ -1,6 +1,7 @@
 package calendar
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -23,6 +24,12 @@ func TestNewOptions(t *testing.T) {
 		ShowComments:     true,
 		UseShortDayNames: false,
 		Justify:          "left",
+		Format:           "markdown",
+		Layout:           GridLayout,
+		Columns:          1,
+		DayStart:         8 * time.Hour,
+		DayEnd:           18 * time.Hour,
+		Slot:             30 * time.Minute,
 	}
 
 	// Compare using cmp.Diff
@@ -30,3 +37,200 @@ func TestNewOptions(t *testing.T) {
 		t.Errorf("NewOptions() mismatch (-want +got):\n%s", diff)
 	}
 }
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name               string
+		modify             func(o *Options)
+		expectedErrs       []error
+		expectedSuggestion string
+	}{
+		{
+			name:   "Defaults are valid",
+			modify: func(o *Options) {},
+		},
+		{
+			name:   "Justify is case-insensitive",
+			modify: func(o *Options) { o.Justify = "Center" },
+		},
+		{
+			name:               "Misspelled justify",
+			modify:             func(o *Options) { o.Justify = "centre" },
+			expectedErrs:       []error{ErrInvalidJustify},
+			expectedSuggestion: "center",
+		},
+		{
+			name:               "Unknown format",
+			modify:             func(o *Options) { o.Format = "markdwn" },
+			expectedErrs:       []error{ErrUnknownFormat},
+			expectedSuggestion: "markdown",
+		},
+		{
+			name:               "Unknown layout",
+			modify:             func(o *Options) { o.Layout = "liner" },
+			expectedErrs:       []error{ErrUnknownLayout},
+			expectedSuggestion: "linear",
+		},
+		{
+			name: "Layout without markdown format",
+			modify: func(o *Options) {
+				if _, ok := LookupRenderer("validate-stub"); !ok {
+					RegisterRenderer("validate-stub", stubRenderer{})
+				}
+				o.Format = "validate-stub"
+				o.Layout = "linear"
+			},
+			expectedErrs: []error{ErrUnsupportedLayout},
+		},
+		{
+			name:         "Zero columns",
+			modify:       func(o *Options) { o.Columns = 0 },
+			expectedErrs: []error{ErrInvalidColumns},
+		},
+		{
+			name: "Columns with another layout",
+			modify: func(o *Options) {
+				o.Layout = "linear"
+				o.Columns = 3
+			},
+			expectedErrs: []error{ErrUnsupportedColumns},
+		},
+		{
+			name: "Columns with a single-month renderer",
+			modify: func(o *Options) {
+				if _, ok := LookupRenderer("validate-stub"); !ok {
+					RegisterRenderer("validate-stub", stubRenderer{})
+				}
+				o.Format = "validate-stub"
+				o.Columns = 3
+			},
+			expectedErrs: []error{ErrUnsupportedColumns},
+		},
+		{
+			name: "Reversed hours in week layout",
+			modify: func(o *Options) {
+				o.Layout = "week"
+				o.DayStart = 18 * time.Hour
+				o.DayEnd = 8 * time.Hour
+			},
+			expectedErrs: []error{ErrInvalidHours},
+		},
+		{
+			name: "Empty slot in week layout",
+			modify: func(o *Options) {
+				o.Layout = "week"
+				o.Slot = 0
+			},
+			expectedErrs: []error{ErrInvalidSlot},
+		},
+		{
+			name:   "Hours are ignored outside the week layout",
+			modify: func(o *Options) { o.Slot = 0 },
+		},
+		{
+			name:         "Habits layout without habits",
+			modify:       func(o *Options) { o.Layout = "habits" },
+			expectedErrs: []error{ErrNoHabits},
+		},
+		{
+			name: "Transpose with columns",
+			modify: func(o *Options) {
+				o.Transpose = true
+				o.Columns = 2
+			},
+			expectedErrs: []error{ErrUnsupportedTranspose},
+		},
+		{
+			name: "Transpose with another layout",
+			modify: func(o *Options) {
+				o.Transpose = true
+				o.Layout = "agenda"
+			},
+			expectedErrs: []error{ErrUnsupportedTranspose},
+		},
+		{
+			name:         "Year out of range",
+			modify:       func(o *Options) { o.Year = 10000 },
+			expectedErrs: []error{ErrInvalidYear},
+		},
+		{
+			name:         "Month out of range",
+			modify:       func(o *Options) { o.Month = intPtr(13) },
+			expectedErrs: []error{ErrInvalidMonth},
+		},
+		{
+			name:         "Weekday out of range",
+			modify:       func(o *Options) { o.FirstDayOfWeek = time.Weekday(7) },
+			expectedErrs: []error{ErrInvalidWeekday},
+		},
+		{
+			name:         "End year without end month",
+			modify:       func(o *Options) { o.EndYear = intPtr(2030) },
+			expectedErrs: []error{ErrIncompleteRange},
+		},
+		{
+			name: "Reversed range",
+			modify: func(o *Options) {
+				o.Year = 2025
+				o.Month = intPtr(6)
+				o.EndYear = intPtr(2025)
+				o.EndMonth = intPtr(2)
+			},
+			expectedErrs: []error{ErrRangeReversed},
+		},
+		{
+			name: "Multiple problems are all reported",
+			modify: func(o *Options) {
+				o.Justify = "middle"
+				o.Month = intPtr(0)
+			},
+			expectedErrs: []error{ErrInvalidMonth, ErrInvalidJustify},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := NewOptions()
+			tt.modify(&options)
+			err := options.Validate()
+
+			if len(tt.expectedErrs) == 0 {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+
+			for _, expected := range tt.expectedErrs {
+				if !errors.Is(err, expected) {
+					t.Errorf("Validate() = %v, want it to wrap %v", err, expected)
+				}
+			}
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Validate() = %v, want a *ValidationError", err)
+			}
+			if validationErr.Suggestion != tt.expectedSuggestion {
+				t.Errorf("Validate() suggestion = %q, want %q", validationErr.Suggestion, tt.expectedSuggestion)
+			}
+		})
+	}
+}
+
+func TestParseWeekday(t *testing.T) {
+	weekday, err := ParseWeekday("Sun")
+	if err != nil || weekday != time.Sunday {
+		t.Errorf("ParseWeekday(%q) = (%v, %v), want (Sunday, nil)", "Sun", weekday, err)
+	}
+
+	_, err = ParseWeekday("mnoday")
+	if !errors.Is(err, ErrInvalidWeekday) {
+		t.Fatalf("ParseWeekday(%q) error = %v, want %v", "mnoday", err, ErrInvalidWeekday)
+	}
+
+	expected := `FirstDayOfWeek "mnoday": invalid weekday (did you mean "monday"?)`
+	if err.Error() != expected {
+		t.Errorf("ParseWeekday(%q) error message = %q, want %q", "mnoday", err.Error(), expected)
+	}
+}
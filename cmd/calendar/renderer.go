@@ -0,0 +1,61 @@
+package calendar
+
+import (
+	"sort"
+	"sync"
+)
+
+// Renderer converts a computed month into a specific output syntax
+type Renderer interface {
+	RenderMonth(month Month, options Options) string
+}
+
+// MultiMonthRenderer is implemented by renderers that can place several months side by side,
+// which is required for Options.Columns greater than one
+type MultiMonthRenderer interface {
+	Renderer
+	RenderMonths(months []Month, options Options) string
+}
+
+var (
+	renderersMu sync.RWMutex
+	renderers   = make(map[string]Renderer)
+)
+
+// RegisterRenderer makes a renderer available by name for use with Options.Format.
+// It panics if the renderer is nil or if a renderer with the same name is already registered.
+func RegisterRenderer(name string, renderer Renderer) {
+	renderersMu.Lock()
+	defer renderersMu.Unlock()
+
+	if renderer == nil {
+		panic("calendar: RegisterRenderer renderer is nil")
+	}
+	if _, dup := renderers[name]; dup {
+		panic("calendar: RegisterRenderer called twice for renderer " + name)
+	}
+	renderers[name] = renderer
+}
+
+// LookupRenderer returns the renderer registered under the given name
+func LookupRenderer(name string) (Renderer, bool) {
+	renderersMu.RLock()
+	defer renderersMu.RUnlock()
+
+	renderer, ok := renderers[name]
+	return renderer, ok
+}
+
+// RendererNames returns the sorted names of all registered renderers
+func RendererNames() []string {
+	renderersMu.RLock()
+	defer renderersMu.RUnlock()
+
+	names := make([]string, 0, len(renderers))
+	for name := range renderers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
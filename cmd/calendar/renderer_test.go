@@ -0,0 +1,65 @@
+package calendar
+
+import (
+	"errors"
+	"testing"
+)
+
+type stubRenderer struct{}
+
+func (stubRenderer) RenderMonth(month Month, options Options) string {
+	return month.Month.String() + "\n"
+}
+
+func TestRegisterRenderer(t *testing.T) {
+	RegisterRenderer("stub", stubRenderer{})
+	defer func() {
+		renderersMu.Lock()
+		delete(renderers, "stub")
+		renderersMu.Unlock()
+	}()
+
+	if _, ok := LookupRenderer("stub"); !ok {
+		t.Fatalf("LookupRenderer(%q) not found after registration", "stub")
+	}
+
+	options := NewOptions()
+	options.Year = 2025
+	options.Month = intPtr(3)
+	options.Format = "stub"
+	if actual, err := PrintCalendar(options); err != nil || actual != "March\n" {
+		t.Errorf("PrintCalendar() = (%q, %v), want %q", actual, err, "March\n")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("RegisterRenderer() did not panic on duplicate name")
+		}
+	}()
+	RegisterRenderer("stub", stubRenderer{})
+}
+
+func TestLookupRendererUnknown(t *testing.T) {
+	if _, ok := LookupRenderer("nope"); ok {
+		t.Errorf("LookupRenderer(%q) found a renderer, want none", "nope")
+	}
+}
+
+func TestPrintCalendarUnknownFormat(t *testing.T) {
+	options := NewOptions()
+	options.Month = intPtr(1)
+	options.Format = "markdwon"
+
+	actual, err := PrintCalendar(options)
+	if !errors.Is(err, ErrUnknownFormat) {
+		t.Fatalf("PrintCalendar() error = %v, want %v", err, ErrUnknownFormat)
+	}
+	if actual != "" {
+		t.Errorf("PrintCalendar() = %q, want empty output on error", actual)
+	}
+
+	expected := `Format "markdwon": unknown format (did you mean "markdown"?)`
+	if err.Error() != expected {
+		t.Errorf("PrintCalendar() error message = %q, want %q", err.Error(), expected)
+	}
+}
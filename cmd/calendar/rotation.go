@@ -0,0 +1,275 @@
+package calendar
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rotation hands a duty, such as being on call, from one person to the next in turns
+type Rotation struct {
+	People       []string
+	Shifts       []string                  // names of the shifts of every day, such as day and night; nil for a single shift
+	Start        time.Time                 // first day of the first turn, which is taken by the first person
+	Pattern      []int                     // lengths of successive turns in days, repeated, such as 2, 2, 3
+	Continuous   bool                      // count skipped days towards the turns instead of pausing the rotation on them
+	SkipWeekends bool                      // leave Saturdays and Sundays uncovered
+	Skip         func(date time.Time) bool // days nobody is on duty, such as holidays; nil skips none
+	Overrides    map[string]string         // people taking over, keyed by YYYY-MM-DD for every shift or YYYY-MM-DD/shift
+}
+
+// Duty is the person on duty for a shift of a day
+type Duty struct {
+	Shift   string // empty for a single shift
+	Person  string
+	Swapped bool // taken over from the rotation by an override
+}
+
+// Duties are the duties of each day, keyed by date in time.DateOnly format
+type Duties map[string][]Duty
+
+// dayOf returns the date at midnight UTC, so that days can be counted without time zone or summer time shifts
+func dayOf(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// floorMod returns a modulo b in the range 0 to b-1, also for negative a
+func floorMod(a int, b int) int {
+	return ((a % b) + b) % b
+}
+
+// skipped reports whether nobody is on duty on the date
+func (r Rotation) skipped(date time.Time) bool {
+	return (r.SkipWeekends && isWeekend(date.Weekday())) || (r.Skip != nil && r.Skip(date))
+}
+
+// turnAt returns the number of the turn of the day offset days after Start, counting turns before Start
+// backwards from -1
+func (r Rotation) turnAt(offset int) int {
+	cycle := 0
+	for _, length := range r.Pattern {
+		cycle += length
+	}
+
+	repeats := (offset - floorMod(offset, cycle)) / cycle
+	rest := floorMod(offset, cycle)
+	turn := repeats * len(r.Pattern)
+	for _, length := range r.Pattern {
+		if rest < length {
+			break
+		}
+		rest -= length
+		turn++
+	}
+	return turn
+}
+
+// turns returns the turn of every day between start and end that somebody is on duty, keyed by date. Unless the
+// rotation is continuous, turns only count the days that are not skipped, and nobody is on duty before Start.
+func (r Rotation) turns(start time.Time, end time.Time) map[string]int {
+	first, last := dayOf(start), dayOf(end)
+	begin := dayOf(r.Start)
+
+	turns := make(map[string]int)
+	if r.Continuous {
+		for cur := first; !cur.After(last); cur = cur.AddDate(0, 0, 1) {
+			if !r.skipped(cur) {
+				turns[cur.Format(time.DateOnly)] = r.turnAt(int(cur.Sub(begin).Hours() / 24))
+			}
+		}
+		return turns
+	}
+
+	counted := 0
+	for cur := begin; !cur.After(last); cur = cur.AddDate(0, 0, 1) {
+		if r.skipped(cur) {
+			continue
+		}
+		if !cur.Before(first) {
+			turns[cur.Format(time.DateOnly)] = r.turnAt(counted)
+		}
+		counted++
+	}
+	return turns
+}
+
+// override returns the person taking over the shift of the date, reporting false when there is none
+func (r Rotation) override(date string, shift string) (string, bool) {
+	if shift != "" {
+		if person, ok := r.Overrides[date+"/"+shift]; ok {
+			return person, true
+		}
+	}
+	person, ok := r.Overrides[date]
+	return person, ok
+}
+
+// Between returns the duties of the days between start and end. With several shifts a day, the people of the
+// shifts are spread evenly over the rotation, so that day and night shifts with two people alternate between them.
+func (r Rotation) Between(start time.Time, end time.Time) Duties {
+	duties := make(Duties)
+	if len(r.People) == 0 || len(r.Pattern) == 0 {
+		return duties
+	}
+
+	shifts := r.Shifts
+	if len(shifts) == 0 {
+		shifts = []string{""}
+	}
+	turns := r.turns(start, end)
+
+	for cur := dayOf(start); !cur.After(dayOf(end)); cur = cur.AddDate(0, 0, 1) {
+		date := cur.Format(time.DateOnly)
+		turn, onDuty := turns[date]
+		for i, shift := range shifts {
+			var duty Duty
+			if onDuty {
+				duty = Duty{Shift: shift, Person: r.People[floorMod(turn+i*len(r.People)/len(shifts), len(r.People))]}
+			}
+			if person, ok := r.override(date, shift); ok && person != duty.Person {
+				duty = Duty{Shift: shift, Person: person, Swapped: true}
+			}
+			if duty.Person != "" {
+				duties[date] = append(duties[date], duty)
+			}
+		}
+	}
+
+	return duties
+}
+
+// text returns the text of the duty, such as "alice", "night: bob" or "carol (swap)"
+func (d Duty) text() string {
+	text := d.Person
+	if d.Shift != "" {
+		text = d.Shift + ": " + text
+	}
+	if d.Swapped {
+		text += " (swap)"
+	}
+	return text
+}
+
+// Annotator returns an annotator that names the people on duty each day, such as "👤 alice". With byWeek only
+// swaps are named, as the week labels name the people of each week.
+func (d Duties) Annotator(byWeek bool) Annotator {
+	return func(date time.Time) []Annotation {
+		var annotations []Annotation
+		for _, duty := range d[date.Format(time.DateOnly)] {
+			if byWeek && !duty.Swapped {
+				continue
+			}
+			annotations = append(annotations, Annotation{Text: "👤 " + duty.text()})
+		}
+		return annotations
+	}
+}
+
+// WeekLabel returns an Options.WeekLabel function that appends the people on duty in each week, starting on
+// firstDayOfWeek, to the week label, such as "11 · alice → bob" for a handoff during the week. Swaps are left out.
+// The label is produced by label, or is the week number when label is nil.
+func (d Duties) WeekLabel(label func(date time.Time, text string) string, shifts []string,
+	firstDayOfWeek time.Weekday) func(date time.Time, text string) string {
+	if len(shifts) == 0 {
+		shifts = []string{""}
+	}
+
+	return func(date time.Time, text string) string {
+		if label != nil {
+			text = label(date, text)
+		}
+
+		weekStart := dayOf(date).AddDate(0, 0, -floorMod(int(date.Weekday())-int(firstDayOfWeek), 7))
+		var parts []string
+		for _, shift := range shifts {
+			var people []string
+			for cur := weekStart; cur.Before(weekStart.AddDate(0, 0, 7)); cur = cur.AddDate(0, 0, 1) {
+				for _, duty := range d[cur.Format(time.DateOnly)] {
+					if duty.Shift == shift && !duty.Swapped && (len(people) == 0 || people[len(people)-1] != duty.Person) {
+						people = append(people, duty.Person)
+					}
+				}
+			}
+			if len(people) == 0 {
+				continue
+			}
+			part := strings.Join(people, " → ")
+			if shift != "" {
+				part = shift + ": " + part
+			}
+			parts = append(parts, part)
+		}
+
+		if len(parts) > 0 {
+			text = appendWeekLabel(text, strings.Join(parts, ", "))
+		}
+		return text
+	}
+}
+
+// Summary returns a table of the number of days each person is on duty, of which weekend days, and with several
+// shifts their days per shift. People are listed in the given order, followed by those who only take over swaps.
+func (d Duties) Summary(people []string, shifts []string, justify string) string {
+	type count struct {
+		days, weekends int
+		shifts         map[string]int
+	}
+	counts := make(map[string]*count)
+	order := append([]string(nil), people...)
+	for _, person := range people {
+		counts[person] = &count{shifts: make(map[string]int)}
+	}
+
+	dates := make([]string, 0, len(d))
+	for date := range d {
+		dates = append(dates, date)
+	}
+	// Dates in YYYY-MM-DD form sort as strings, which keeps the people taking over swaps in order of their first swap
+	sort.Strings(dates)
+
+	for _, date := range dates {
+		day, err := time.Parse(time.DateOnly, date)
+		if err != nil {
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, duty := range d[date] {
+			c, ok := counts[duty.Person]
+			if !ok {
+				c = &count{shifts: make(map[string]int)}
+				counts[duty.Person] = c
+				order = append(order, duty.Person)
+			}
+			c.shifts[duty.Shift]++
+			if seen[duty.Person] {
+				continue
+			}
+			seen[duty.Person] = true
+			c.days++
+			if isWeekend(day.Weekday()) {
+				c.weekends++
+			}
+		}
+	}
+
+	headers := []string{"Person", "Days", "Weekend days"}
+	if len(shifts) > 1 {
+		for _, shift := range shifts {
+			headers = append(headers, escapeCell(shift))
+		}
+	}
+	var rows [][]string
+	for _, person := range order {
+		c := counts[person]
+		row := []string{escapeCell(person), strconv.Itoa(c.days), strconv.Itoa(c.weekends)}
+		if len(shifts) > 1 {
+			for _, shift := range shifts {
+				row = append(row, strconv.Itoa(c.shifts[shift]))
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return generateTable(headers, rows, justify)
+}
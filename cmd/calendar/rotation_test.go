@@ -0,0 +1,197 @@
+package calendar
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRotationBetween(t *testing.T) {
+	date := func(day int) time.Time {
+		return time.Date(2025, time.March, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		rotation Rotation
+		start    time.Time
+		end      time.Time
+		expected Duties
+	}{
+		{
+			name: "Weekly turns across a holiday",
+			rotation: Rotation{
+				People:     []string{"alice", "bob"},
+				Start:      date(3),
+				Pattern:    []int{7},
+				Continuous: true,
+				Skip:       func(date time.Time) bool { return date.Day() == 10 },
+			},
+			start: date(9),
+			end:   date(11),
+			expected: Duties{
+				"2025-03-09": {{Person: "alice"}},
+				"2025-03-11": {{Person: "bob"}},
+			},
+		},
+		{
+			name:     "Turns before the start",
+			rotation: Rotation{People: []string{"alice", "bob", "carol"}, Start: date(10), Pattern: []int{7}, Continuous: true},
+			start:    date(2),
+			end:      date(3),
+			expected: Duties{
+				"2025-03-02": {{Person: "bob"}},
+				"2025-03-03": {{Person: "carol"}},
+			},
+		},
+		{
+			name:     "Daily turns pause on skipped days",
+			rotation: Rotation{People: []string{"ann", "ben", "cat"}, Start: date(6), Pattern: []int{1}, SkipWeekends: true},
+			start:    date(5),
+			end:      date(11),
+			expected: Duties{
+				"2025-03-06": {{Person: "ann"}},
+				"2025-03-07": {{Person: "ben"}},
+				"2025-03-10": {{Person: "cat"}},
+				"2025-03-11": {{Person: "ann"}},
+			},
+		},
+		{
+			name: "Pattern with day and night shifts",
+			rotation: Rotation{
+				People:     []string{"alice", "bob"},
+				Shifts:     []string{"day", "night"},
+				Start:      date(3),
+				Pattern:    []int{2, 3},
+				Continuous: true,
+			},
+			start: date(4),
+			end:   date(6),
+			expected: Duties{
+				"2025-03-04": {{Shift: "day", Person: "alice"}, {Shift: "night", Person: "bob"}},
+				"2025-03-05": {{Shift: "day", Person: "bob"}, {Shift: "night", Person: "alice"}},
+				"2025-03-06": {{Shift: "day", Person: "bob"}, {Shift: "night", Person: "alice"}},
+			},
+		},
+		{
+			name: "Overrides",
+			rotation: Rotation{
+				People:     []string{"alice", "bob"},
+				Shifts:     []string{"day", "night"},
+				Start:      date(3),
+				Pattern:    []int{7},
+				Continuous: true,
+				Skip:       func(date time.Time) bool { return date.Day() == 4 },
+				Overrides:  map[string]string{"2025-03-03/night": "dave", "2025-03-04": "carol", "2025-03-05/day": "alice"},
+			},
+			start: date(3),
+			end:   date(4),
+			expected: Duties{
+				"2025-03-03": {{Shift: "day", Person: "alice"}, {Shift: "night", Person: "dave", Swapped: true}},
+				"2025-03-04": {{Shift: "day", Person: "carol", Swapped: true}, {Shift: "night", Person: "carol", Swapped: true}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.expected, tt.rotation.Between(tt.start, tt.end)); diff != "" {
+				t.Errorf("Between() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDutiesAnnotator(t *testing.T) {
+	duties := Duties{
+		"2025-03-03": {{Person: "alice"}},
+		"2025-03-04": {{Shift: "night", Person: "bob"}, {Shift: "day", Person: "carol", Swapped: true}},
+	}
+	date := func(day int) time.Time {
+		return time.Date(2025, time.March, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		byWeek   bool
+		date     time.Time
+		expected []Annotation
+	}{
+		{name: "Single shift", date: date(3), expected: []Annotation{{Text: "👤 alice"}}},
+		{name: "Shifts and swaps", date: date(4), expected: []Annotation{{Text: "👤 night: bob"}, {Text: "👤 day: carol (swap)"}}},
+		{name: "By week", byWeek: true, date: date(3)},
+		{name: "Swaps by week", byWeek: true, date: date(4), expected: []Annotation{{Text: "👤 day: carol (swap)"}}},
+		{name: "Day without duty", date: date(5)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.expected, duties.Annotator(tt.byWeek)(tt.date)); diff != "" {
+				t.Errorf("Annotator() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDutiesWeekLabel(t *testing.T) {
+	duties := Rotation{People: []string{"alice", "bob"}, Start: time.Date(2025, time.March, 5, 0, 0, 0, 0, time.UTC),
+		Pattern: []int{7}, Continuous: true}.Between(time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC))
+	shifts := Rotation{People: []string{"a|b", "c"}, Shifts: []string{"day", "night"}, Start: time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC),
+		Pattern: []int{7}, Continuous: true}.Between(time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, time.March, 9, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name     string
+		duties   Duties
+		shifts   []string
+		label    func(date time.Time, text string) string
+		start    time.Weekday
+		date     time.Time
+		expected string
+	}{
+		{name: "Handoff during the week", duties: duties, start: time.Monday, date: time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC), expected: "11 · alice → bob"},
+		{name: "Week starting on the handoff", duties: duties, start: time.Wednesday, date: time.Date(2025, time.March, 12, 0, 0, 0, 0, time.UTC), expected: "11 · bob"},
+		{name: "Week without duties", duties: duties, start: time.Monday, date: time.Date(2025, time.April, 7, 0, 0, 0, 0, time.UTC), expected: "15"},
+		{
+			name:     "Shifts",
+			duties:   shifts,
+			shifts:   []string{"day", "night"},
+			label:    func(date time.Time, text string) string { return "W" + text },
+			start:    time.Monday,
+			date:     time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC),
+			expected: "W10 · day: a\\|b, night: c",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, week := tt.date.ISOWeek()
+			got := tt.duties.WeekLabel(tt.label, tt.shifts, tt.start)(tt.date, strconv.Itoa(week))
+			if diff := cmp.Diff(tt.expected, got); diff != "" {
+				t.Errorf("WeekLabel() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDutiesSummary(t *testing.T) {
+	duties := Duties{
+		"2025-03-08": {{Shift: "day", Person: "alice"}, {Shift: "night", Person: "bob"}},
+		"2025-03-10": {{Shift: "day", Person: "alice"}, {Shift: "night", Person: "alice"}},
+		"2025-03-11": {{Shift: "day", Person: "dave", Swapped: true}, {Shift: "night", Person: "bob"}},
+	}
+
+	expected := "| Person | Days | Weekend days | day | night |\n" +
+		"| :----- | :--- | :----------- | :-- | :---- |\n" +
+		"| alice  | 2    | 1            | 2   | 1     |\n" +
+		"| bob    | 2    | 1            | 0   | 2     |\n" +
+		"| carol  | 0    | 0            | 0   | 0     |\n" +
+		"| dave   | 1    | 0            | 1   | 0     |\n"
+
+	if diff := cmp.Diff(expected, duties.Summary([]string{"alice", "bob", "carol"}, []string{"day", "night"}, "left")); diff != "" {
+		t.Errorf("Summary() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,96 @@
+package calendar
+
+import (
+	"strconv"
+	"time"
+)
+
+// Sprints is a cadence of sprints of equal length, numbered from 1 for the sprint starting on Start
+type Sprints struct {
+	Start  time.Time
+	Length int                                                // length of every sprint in days
+	Name   func(n int, start time.Time, end time.Time) string // nil to name sprints "Sprint N"
+}
+
+// at returns the number and the first and last day of the sprint of date, reporting false before the first sprint
+func (s Sprints) at(date time.Time) (int, time.Time, time.Time, bool) {
+	start := time.Date(s.Start.Year(), s.Start.Month(), s.Start.Day(), 0, 0, 0, 0, time.UTC)
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	if s.Length < 1 || day.Before(start) {
+		return 0, time.Time{}, time.Time{}, false
+	}
+
+	n := int(day.Sub(start).Hours()/24)/s.Length + 1
+	sprintStart := start.AddDate(0, 0, (n-1)*s.Length)
+	return n, sprintStart, sprintStart.AddDate(0, 0, s.Length-1), true
+}
+
+// name returns the name of the sprint
+func (s Sprints) name(n int, start time.Time, end time.Time) string {
+	if s.Name == nil {
+		return "Sprint " + strconv.Itoa(n)
+	}
+	return s.Name(n, start, end)
+}
+
+// WeekLabel returns an Options.WeekLabel function that appends the name of the sprint of each week, starting on
+// firstDayOfWeek, to the week label, such as "11 · Sprint 6". A week that spans two sprints is labelled with the
+// sprint covering most of its days, or with the later one when both cover as many. The label is produced by label,
+// or is the week number when label is nil.
+func (s Sprints) WeekLabel(label func(date time.Time, text string) string, firstDayOfWeek time.Weekday) func(date time.Time, text string) string {
+	return func(date time.Time, text string) string {
+		if label != nil {
+			text = label(date, text)
+		}
+
+		day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+		weekStart := day.AddDate(0, 0, -((int(day.Weekday()) - int(firstDayOfWeek) + 7) % 7))
+		var n, days int
+		var start, end time.Time
+		counts := make(map[int]int)
+		for cur := weekStart; cur.Before(weekStart.AddDate(0, 0, 7)); cur = cur.AddDate(0, 0, 1) {
+			sprint, sprintStart, sprintEnd, ok := s.at(cur)
+			if !ok {
+				continue
+			}
+			counts[sprint]++
+			if counts[sprint] >= days {
+				n, days, start, end = sprint, counts[sprint], sprintStart, sprintEnd
+			}
+		}
+		if n > 0 {
+			text = appendWeekLabel(text, s.name(n, start, end))
+		}
+		return text
+	}
+}
+
+// reviewDay returns the last weekday from Monday to Friday of the sprint ending on end
+func reviewDay(start time.Time, end time.Time) time.Time {
+	for end.After(start) && (end.Weekday() == time.Saturday || end.Weekday() == time.Sunday) {
+		end = end.AddDate(0, 0, -1)
+	}
+	return end
+}
+
+// Annotator returns an annotator that marks the first day of every sprint with its planning, such as
+// "▶ Sprint 6: planning", and its last weekday with its review
+func (s Sprints) Annotator() Annotator {
+	return func(date time.Time) []Annotation {
+		n, start, end, ok := s.at(date)
+		if !ok {
+			return nil
+		}
+
+		name := s.name(n, start, end)
+		day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+		var annotations []Annotation
+		if day.Equal(start) {
+			annotations = append(annotations, Annotation{Text: "▶ " + name + ": planning"})
+		}
+		if day.Equal(reviewDay(start, end)) {
+			annotations = append(annotations, Annotation{Text: name + ": review"})
+		}
+		return annotations
+	}
+}
@@ -0,0 +1,74 @@
+package calendar
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSprintsAnnotator(t *testing.T) {
+	sprints := Sprints{Start: time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC), Length: 14}
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		date     time.Time
+		expected []Annotation
+	}{
+		{name: "Before the first sprint", date: date(2025, time.January, 5)},
+		{name: "First day", date: date(2025, time.January, 6), expected: []Annotation{{Text: "▶ Sprint 1: planning"}}},
+		{name: "Last weekday", date: date(2025, time.January, 17), expected: []Annotation{{Text: "Sprint 1: review"}}},
+		{name: "Last day on a weekend", date: date(2025, time.January, 19)},
+		{name: "Next year", date: date(2026, time.January, 5), expected: []Annotation{{Text: "▶ Sprint 27: planning"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.expected, sprints.Annotator()(tt.date)); diff != "" {
+				t.Errorf("Annotator() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSprintsWeekLabel(t *testing.T) {
+	sprints := Sprints{
+		Start:  time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC),
+		Length: 14,
+		Name: func(n int, start time.Time, end time.Time) string {
+			return "S" + strings.Repeat("I", n) + " to " + end.Format("01-02")
+		},
+	}
+	link := func(date time.Time, text string) string { return "[[W" + text + "]]" }
+
+	tests := []struct {
+		name     string
+		label    func(date time.Time, text string) string
+		start    time.Weekday
+		date     time.Time
+		expected string
+	}{
+		{name: "Before the first sprint", start: time.Monday, date: time.Date(2024, time.December, 30, 0, 0, 0, 0, time.UTC), expected: "1"},
+		{name: "Second sprint", start: time.Monday, date: time.Date(2025, time.January, 20, 0, 0, 0, 0, time.UTC), expected: "4 · SII to 02-02"},
+		{name: "Composed label", label: link, start: time.Monday, date: time.Date(2025, time.January, 13, 0, 0, 0, 0, time.UTC), expected: "[[W3]] · SI to 01-19"},
+		{name: "Sunday week before the first sprint", start: time.Sunday, date: time.Date(2025, time.January, 5, 0, 0, 0, 0, time.UTC), expected: "1 · SI to 01-19"},
+		{name: "Sunday week mostly in the second sprint", start: time.Sunday, date: time.Date(2025, time.January, 19, 0, 0, 0, 0, time.UTC), expected: "3 · SII to 02-02"},
+		{name: "Sunday week before the sprints", start: time.Sunday, date: time.Date(2024, time.December, 29, 0, 0, 0, 0, time.UTC), expected: "52"},
+		{name: "Thursday week mostly in the first sprint", start: time.Thursday, date: time.Date(2025, time.January, 16, 0, 0, 0, 0, time.UTC), expected: "3 · SI to 01-19"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, week := tt.date.ISOWeek()
+			actual := sprints.WeekLabel(tt.label, tt.start)(tt.date, strconv.Itoa(week))
+			if actual != tt.expected {
+				t.Errorf("WeekLabel() = %q, want %q", actual, tt.expected)
+			}
+		})
+	}
+}
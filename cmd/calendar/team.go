@@ -0,0 +1,153 @@
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Member is a person of a team whose availability is shown by WriteTeam
+type Member struct {
+	Name     string
+	Workdays []time.Weekday    // days the member works; nil for Monday to Friday
+	Holidays map[string]string // public holidays of the member, keyed by date in time.DateOnly format
+	Away     map[string]bool   // days off, such as PTO, keyed by date in time.DateOnly format
+}
+
+// Availability cells of the team table
+const (
+	teamIn      = "in"
+	teamOut     = "out"
+	teamHoliday = "holiday"
+	teamOff     = "–"
+)
+
+// works reports whether the weekday is one of the working days of the member
+func (m Member) works(weekday time.Weekday) bool {
+	if m.Workdays == nil {
+		return !isWeekend(weekday)
+	}
+	return slices.Contains(m.Workdays, weekday)
+}
+
+// status returns the availability of the member on the date: off on days they do not work, then holiday, out or in
+func (m Member) status(date time.Time) string {
+	day := date.Format(time.DateOnly)
+	if !m.works(date.Weekday()) {
+		return teamOff
+	}
+	if _, ok := m.Holidays[day]; ok {
+		return teamHoliday
+	}
+	if m.Away[day] {
+		return teamOut
+	}
+	return teamIn
+}
+
+// generateTeamMonth creates the availability table of a month, with a row per displayed day and a totals row after
+// the last displayed day of every week
+func generateTeamMonth(firstOfMonth time.Time, options Options, members []Member) string {
+	var sb strings.Builder
+
+	sb.WriteString(generateCalendarHeader(firstOfMonth.Year(), firstOfMonth.Month()))
+
+	columnHeaders := []string{"Date", "Weekday"}
+	if options.ShowCalendarWeek {
+		columnHeaders = append(columnHeaders, "CW")
+	}
+	for _, m := range members {
+		columnHeaders = append(columnHeaders, escapeCell(m.Name))
+	}
+	columnHeaders = append(columnHeaders, "Headcount")
+
+	var rows [][]string
+	totals := make([]int, len(members))
+	pending := false
+	addTotals := func() {
+		row := []string{"**Total**", ""}
+		if options.ShowCalendarWeek {
+			row = append(row, "")
+		}
+		sum := 0
+		for i, total := range totals {
+			row = append(row, "**"+strconv.Itoa(total)+"**")
+			sum += total
+			totals[i] = 0
+		}
+		rows = append(rows, append(row, "**"+strconv.Itoa(sum)+"**"))
+		pending = false
+	}
+
+	for date := firstOfMonth; date.Month() == firstOfMonth.Month(); date = date.AddDate(0, 0, 1) {
+		if date.Weekday() == options.FirstDayOfWeek && pending {
+			addTotals()
+		}
+		if !options.ShowWeekends && isWeekend(date.Weekday()) {
+			continue
+		}
+
+		weekday := date.Weekday().String()
+		if options.UseShortDayNames {
+			weekday = weekday[:3]
+		}
+		row := []string{options.dayLabel(date, date.Format(time.DateOnly)), weekday}
+		if options.ShowCalendarWeek {
+			row = append(row, "_"+options.weekLabel(date)+"_")
+		}
+		headcount := 0
+		for i, m := range members {
+			status := m.status(date)
+			if status == teamIn {
+				headcount++
+				totals[i]++
+			}
+			row = append(row, status)
+		}
+		rows = append(rows, append(row, strconv.Itoa(headcount)))
+		pending = true
+	}
+	if pending {
+		addTotals()
+	}
+
+	sb.WriteString(generateTable(columnHeaders, rows, options.Justify))
+
+	return sb.String()
+}
+
+// WriteTeam streams the availability of the members on the days of the range selected by options, with one table
+// per month, a column per member showing whether they are in, out, on a holiday or not working (–), and the
+// headcount of each day. A totals row after every week gives the days each member is in and the person-days of the
+// team. Weeks that span two months are totalled in each month separately. Other layouts, columns, transposing and
+// annotators are reported as errors, as team calendars do not show them.
+func WriteTeam(w io.Writer, options Options, members []Member) error {
+	var annotatorsErr error
+	if len(options.Annotators) > 0 {
+		annotatorsErr = &ValidationError{Field: "Annotators", Value: fmt.Sprint(len(options.Annotators)), Err: ErrUnsupportedTeam}
+	}
+	if err := errors.Join(options.Validate(), validateOwnLayout(options, ErrUnsupportedTeam), annotatorsErr); err != nil {
+		return err
+	}
+	if len(members) == 0 {
+		return ErrNoMembers
+	}
+
+	first := true
+
+	return forEachMonth(options, func(firstOfMonth time.Time) error {
+		output := generateTeamMonth(firstOfMonth, options, members)
+		if !first {
+			// Add a blank line between months
+			output = "\n" + output
+		}
+		first = false
+
+		_, err := io.WriteString(w, output)
+		return err
+	})
+}
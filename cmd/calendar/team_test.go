@@ -0,0 +1,117 @@
+package calendar
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMemberStatus(t *testing.T) {
+	member := Member{
+		Name:     "Alice",
+		Workdays: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday},
+		Holidays: map[string]string{"2025-04-21": "Easter Monday", "2025-04-18": "Good Friday"},
+		Away:     map[string]bool{"2025-04-21": true, "2025-04-22": true},
+	}
+	date := func(day int) time.Time {
+		return time.Date(2025, time.April, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		member   Member
+		date     time.Time
+		expected string
+	}{
+		{name: "Working day", member: member, date: date(23), expected: "in"},
+		{name: "PTO", member: member, date: date(22), expected: "out"},
+		{name: "Holiday during PTO", member: member, date: date(21), expected: "holiday"},
+		{name: "Holiday on a day off", member: member, date: date(18), expected: "–"},
+		{name: "Default workdays", member: Member{Name: "Bob"}, date: date(18), expected: "in"},
+		{name: "Default weekend", member: Member{Name: "Bob"}, date: date(19), expected: "–"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.expected, tt.member.status(tt.date)); diff != "" {
+				t.Errorf("status() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateTeamMonth(t *testing.T) {
+	options := NewOptions()
+	options.UseShortDayNames = true
+	options.ShowWeekends = false
+	members := []Member{
+		{Name: "Alice", Away: map[string]bool{"2025-02-04": true}},
+		{Name: "B|b", Holidays: map[string]string{"2025-02-03": "Founders Day"}},
+	}
+
+	output := generateTeamMonth(time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC), options, members)
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+
+	expected := []string{
+		"# February 2025",
+		"",
+		"| Date       | Weekday | CW  | Alice | B\\|b    | Headcount |",
+		"| :--------- | :------ | :-- | :---- | :------ | :-------- |",
+		"| 2025-02-03 | Mon     | _6_ | in    | holiday | 1         |",
+		"| 2025-02-04 | Tue     | _6_ | out   | in      | 1         |",
+	}
+	if diff := cmp.Diff(expected, lines[:len(expected)]); diff != "" {
+		t.Errorf("generateTeamMonth() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff("| **Total**  |         |     | **4** | **4**   | **8**     |", lines[9]); diff != "" {
+		t.Errorf("generateTeamMonth() weekly total mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff("| **Total**  |         |     | **5** | **5**   | **10**    |", lines[len(lines)-1]); diff != "" {
+		t.Errorf("generateTeamMonth() last total mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWriteTeamWithoutMembers(t *testing.T) {
+	var sb strings.Builder
+	if err := WriteTeam(&sb, NewOptions(), nil); !errors.Is(err, ErrNoMembers) {
+		t.Errorf("WriteTeam() error = %v, want %v", err, ErrNoMembers)
+	}
+}
+
+func TestWriteTeamUnsupportedOptions(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(o *Options)
+		field  string
+	}{
+		{name: "Layout", modify: func(o *Options) { o.Layout = "agenda" }, field: "Layout"},
+		{name: "Columns", modify: func(o *Options) { o.Columns = 2 }, field: "Columns"},
+		{name: "Transpose", modify: func(o *Options) { o.Transpose = true }, field: "Transpose"},
+		{
+			name:   "Annotators",
+			modify: func(o *Options) { o.AddAnnotator(0, Events(map[string][]Annotation{"2025-03-03": {{Text: "Launch"}}})) },
+			field:  "Annotators",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := NewOptions()
+			options.Year = 2025
+			tt.modify(&options)
+
+			var sb strings.Builder
+			err := WriteTeam(&sb, options, []Member{{Name: "Alice"}})
+			var validationErr *ValidationError
+			if !errors.Is(err, ErrUnsupportedTeam) || !errors.As(err, &validationErr) || validationErr.Field != tt.field {
+				t.Fatalf("WriteTeam() error = %v, want %v for %s", err, ErrUnsupportedTeam, tt.field)
+			}
+			if sb.Len() > 0 {
+				t.Errorf("WriteTeam() wrote %q before reporting the error", sb.String())
+			}
+		})
+	}
+}
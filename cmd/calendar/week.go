@@ -0,0 +1,116 @@
+package calendar
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+func init() {
+	layouts["week"] = writeWeekCalendar
+}
+
+// generateWeekHeader creates the heading of a week planner with the week number and the date span
+func generateWeekHeader(weekStart time.Time, weekEnd time.Time, showCalendarWeek bool) string {
+	span := fmt.Sprintf("%s – %s, %d", weekStart.Format("Jan 2"), weekEnd.Format("Jan 2"), weekEnd.Year())
+	if weekStart.Year() != weekEnd.Year() {
+		span = fmt.Sprintf("%s – %s", weekStart.Format("Jan 2, 2006"), weekEnd.Format("Jan 2, 2006"))
+	}
+
+	if !showCalendarWeek {
+		return "# " + span + "\n\n"
+	}
+	_, number := weekStart.ISOWeek()
+	return fmt.Sprintf("# Week %d: %s\n\n", number, span)
+}
+
+// formatClock formats an offset from midnight as a 24-hour clock time
+func formatClock(offset time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(offset.Hours()), int(offset.Minutes())%60)
+}
+
+// generateWeekPlanner creates the time-block table for the week starting at weekStart,
+// with one row per time slot and one column per displayed weekday
+func generateWeekPlanner(weekStart time.Time, options Options, annotators []Annotator) string {
+	var sb strings.Builder
+
+	dayShortNames, dayFullNames := getWeekdayNames(options.FirstDayOfWeek, options.ShowWeekends)
+	weekDays := convertToWeekdays(dayShortNames)
+	dayNames := dayFullNames
+	if options.UseShortDayNames {
+		dayNames = dayShortNames
+	}
+
+	sb.WriteString(generateWeekHeader(weekStart, weekStart.AddDate(0, 0, 6), options.ShowCalendarWeek))
+
+	columnHeaders := []string{"Time"}
+	var dates []time.Time
+	for i, wd := range weekDays {
+		date := weekStart.AddDate(0, 0, (int(wd)-int(options.FirstDayOfWeek)+7)%7)
+		dates = append(dates, date)
+		columnHeaders = append(columnHeaders, options.dayLabel(date, fmt.Sprintf("%s %d", dayNames[i], date.Day())))
+	}
+
+	var rows [][]string
+
+	// Show annotations such as holidays in an all-day row above the time slots
+	allDay := []string{"All day"}
+	hasAnnotations := false
+	for _, date := range dates {
+		annotations := annotate(date, annotators)
+		hasAnnotations = hasAnnotations || len(annotations) > 0
+		allDay = append(allDay, strings.TrimPrefix(formatAnnotations(annotations), "<br>"))
+	}
+	if hasAnnotations {
+		rows = append(rows, allDay)
+	}
+
+	for slot := options.DayStart; slot < options.DayEnd; slot += options.Slot {
+		row := []string{formatClock(slot)}
+		row = append(row, make([]string, len(dates))...)
+		rows = append(rows, row)
+	}
+
+	sb.WriteString(generateTable(columnHeaders, rows, options.Justify))
+
+	return sb.String()
+}
+
+// hasDisplayedDayInRange reports whether any displayed day of the week starting at weekStart falls within the range
+func hasDisplayedDayInRange(weekStart time.Time, start time.Time, end time.Time, showWeekends bool) bool {
+	for date := weekStart; date.Before(weekStart.AddDate(0, 0, 7)); date = date.AddDate(0, 0, 1) {
+		if !date.Before(start) && !date.After(end) && (showWeekends || !isWeekend(date.Weekday())) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeWeekCalendar streams a time-block planner with one table per week covering the selected range
+func writeWeekCalendar(w io.Writer, options Options) error {
+	start, end := options.DateRange()
+	annotators := sortedAnnotators(options.Annotators)
+
+	_, _, weekStart := calculateMonthBoundaries(start.Year(), start.Month(), options.FirstDayOfWeek)
+	first := true
+	for cur := weekStart; !cur.After(end); cur = cur.AddDate(0, 0, 7) {
+		// Skip weeks whose only days in range are hidden weekends
+		if !hasDisplayedDayInRange(cur, start, end, options.ShowWeekends) {
+			continue
+		}
+
+		output := generateWeekPlanner(cur, options, annotators)
+		if !first {
+			// Add a blank line between weeks
+			output = "\n" + output
+		}
+		first = false
+
+		if _, err := io.WriteString(w, output); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGenerateWeekHeader(t *testing.T) {
+	tests := []struct {
+		name             string
+		weekStart        time.Time
+		showCalendarWeek bool
+		expected         string
+	}{
+		{
+			name:             "Week within a year",
+			weekStart:        time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC),
+			showCalendarWeek: true,
+			expected:         "# Week 10: Mar 3 – Mar 9, 2025\n\n",
+		},
+		{
+			name:             "Week across years",
+			weekStart:        time.Date(2025, time.December, 29, 0, 0, 0, 0, time.UTC),
+			showCalendarWeek: true,
+			expected:         "# Week 1: Dec 29, 2025 – Jan 4, 2026\n\n",
+		},
+		{
+			name:             "Without week number",
+			weekStart:        time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC),
+			showCalendarWeek: false,
+			expected:         "# Mar 3 – Mar 9, 2025\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := generateWeekHeader(tt.weekStart, tt.weekStart.AddDate(0, 0, 6), tt.showCalendarWeek)
+			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+				t.Errorf("generateWeekHeader() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateWeekPlanner(t *testing.T) {
+	options := NewOptions()
+	options.UseShortDayNames = true
+	options.ShowWeekends = false
+	options.DayStart = 9 * time.Hour
+	options.DayEnd = 10*time.Hour + 30*time.Minute
+	options.Slot = 45 * time.Minute
+	annotators := []Annotator{Holidays(map[string]string{"2025-03-04": "Carnival"})}
+
+	expected := "# Week 10: Mar 3 – Mar 9, 2025\n\n" +
+		"| Time    | Mon 3 | Tue 4    | Wed 5 | Thu 6 | Fri 7 |\n" +
+		"| :------ | :---- | :------- | :---- | :---- | :---- |\n" +
+		"| All day |       | Carnival |       |       |       |\n" +
+		"| 09:00   |       |          |       |       |       |\n" +
+		"| 09:45   |       |          |       |       |       |\n"
+
+	actual := generateWeekPlanner(time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC), options, annotators)
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("generateWeekPlanner() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWriteWeekCalendar(t *testing.T) {
+	options := NewOptions()
+	options.Year = 2025
+	options.Month = intPtr(3)
+	options.Layout = "week"
+	options.FirstDayOfWeek = time.Sunday
+	options.ShowWeekends = false
+
+	output, err := PrintCalendar(options)
+	if err != nil {
+		t.Fatalf("PrintCalendar() returned error: %v", err)
+	}
+
+	// March 2025 starts on a Saturday and weekends are hidden, so the first displayed day is Monday Mar 3; its week
+	// still spans Sunday Mar 2 to Saturday Mar 8 in the heading, and the week of Mar 1 is left out
+	if !strings.HasPrefix(output, "# Week 9: Mar 2 – Mar 8, 2025\n") {
+		t.Errorf("PrintCalendar() should start with the week of Mar 2:\n%s", output[:80])
+	}
+	if count := strings.Count(output, "# Week "); count != 5 {
+		t.Errorf("PrintCalendar() wrote %d weeks, want 5", count)
+	}
+}
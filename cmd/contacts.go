@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/andre-a-alves/mdcal/cmd/calendar"
+	"github.com/andre-a-alves/mdcal/cmd/sources"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// parseLeapDay parses a --feb29 value, reporting whether February 29 falls on March 1 in other years
+func parseLeapDay(value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "feb28":
+		return false, nil
+	case "mar1":
+		return true, nil
+	}
+	return false, fmt.Errorf("invalid --feb29 %q: expected feb28 or mar1", value)
+}
+
+// addContactSource adds the birthdays and anniversaries of the vCard file named by the flags to the days of the calendar
+func addContactSource(cmd *cobra.Command, options *calendar.Options) error {
+	vcf, _ := cmd.Flags().GetString("vcf")
+	leapDay, _ := cmd.Flags().GetString("feb29")
+
+	marchFirst, err := parseLeapDay(leapDay)
+	if err != nil {
+		return err
+	}
+	if vcf == "" {
+		return nil
+	}
+
+	file, err := os.Open(sources.ExpandHome(vcf))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	dates, warnings, err := sources.ReadVCards(file)
+	if err != nil {
+		return fmt.Errorf("%s: %w", vcf, err)
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(cmd.ErrOrStderr(), "%s: %s\n", vcf, warning)
+	}
+	// Birthdays lead the other events of the day, after holidays
+	options.AddAnnotator(-50, sources.ContactAnnotator(dates, marchFirst))
+	return nil
+}
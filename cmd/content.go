@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/andre-a-alves/mdcal/cmd/calendar"
+	"github.com/andre-a-alves/mdcal/cmd/sources"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+var contentCmd = &cobra.Command{
+	Use:   "content --dir dir [year] [month] [endMonth|endYear endMonth]",
+	Short: "Generate an editorial calendar from the front matter of static site content",
+	Long: `content walks the content files of a Hugo, Jekyll or Astro site below a directory and places the title of
+every post on its publish day, linked to its file. The day is read from the publishDate, pubDate or date field of the
+YAML (---) or TOML (+++) front matter, or from a Jekyll style file name such as 2025-03-14-hello.md. Drafts
+(draft: true or published: false) are marked with 📝.
+Examples:
+  mdcal content --dir content/posts 2025 3         - Publications of March 2025
+  mdcal content --dir _posts --no-drafts -l agenda - Published posts of the current year as a daily log`,
+	Args: cobra.MaximumNArgs(4),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		options, err := initOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		if err := processCommandLineArgs(args, &options); err != nil {
+			return err
+		}
+
+		dir, _ := cmd.Flags().GetString("dir")
+		noDrafts, _ := cmd.Flags().GetBool("no-drafts")
+		output, _ := cmd.Flags().GetString("output")
+
+		posts, warnings, err := sources.ScanContent(sources.ExpandHome(dir))
+		if err != nil {
+			return err
+		}
+		for _, warning := range warnings {
+			fmt.Fprintln(cmd.ErrOrStderr(), warning)
+		}
+		// Links to the posts are relative to the file the calendar is written to
+		annotations, err := sources.PostAnnotations(posts, filepath.Dir(output), !noDrafts)
+		if err != nil {
+			return err
+		}
+		options.AddAnnotator(0, calendar.Events(annotations))
+
+		return writeCalendar(cmd, options)
+	},
+}
+
+func init() {
+	contentCmd.Flags().String("dir", "", "Directory of content files to scan for front matter")
+	contentCmd.Flags().Bool("no-drafts", false, "Leave drafts off the calendar")
+	_ = contentCmd.MarkFlagRequired("dir")
+
+	rootCmd.AddCommand(contentCmd)
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/andre-a-alves/mdcal/cmd/schedule"
+	"github.com/andre-a-alves/mdcal/cmd/sources"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var cronCmd = &cobra.Command{
+	Use:   "cron [year] [month] [endMonth|endYear endMonth]",
+	Short: "Generate a calendar of the days scheduled jobs fire",
+	Long: `cron marks each day a scheduled job fires with its number of runs, or its times with --times, so that
+maintenance and backup schedules can be checked against holidays and month ends. Schedules are read from cron
+expressions, crontab files, the OnCalendar= settings of systemd timers and the schedule of Kubernetes CronJobs,
+and are expanded in the --tz time zone for the days shown only.
+Examples:
+  mdcal cron --expr "0 3 * * 1-5" 2025 3                         - Weekday runs at 03:00 in March 2025
+  crontab -l | mdcal cron --crontab - --times 2025 12            - Times of every job of the current user
+  mdcal cron --timer backup.timer --cronjob jobs.yaml --tz UTC   - Timers and CronJobs of the current year in UTC`,
+	Args: cobra.MaximumNArgs(4),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		options, err := initOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		if err := processCommandLineArgs(args, &options); err != nil {
+			return err
+		}
+
+		zone, _ := cmd.Flags().GetString("tz")
+		showTimes, _ := cmd.Flags().GetBool("times")
+
+		location, err := time.LoadLocation(zone)
+		if err != nil {
+			return fmt.Errorf("invalid --tz %q: %w", zone, err)
+		}
+		jobs, err := readJobs(cmd)
+		if err != nil {
+			return err
+		}
+		options.AddAnnotator(0, sources.JobAnnotator(jobs, location, showTimes))
+
+		return writeCalendar(cmd, options)
+	},
+}
+
+func init() {
+	cronCmd.Flags().StringArray("expr", nil, "Cron expression, such as \"0 3 * * 1-5\" or @daily (repeatable)")
+	cronCmd.Flags().StringArray("crontab", nil, "Crontab file, or - for standard input (repeatable)")
+	cronCmd.Flags().StringArray("timer", nil, "systemd timer unit whose OnCalendar= settings are shown (repeatable)")
+	cronCmd.Flags().StringArray("cronjob", nil, "Kubernetes YAML file whose CronJob schedules are shown (repeatable)")
+	cronCmd.Flags().String("tz", "Local", "Time zone of the calendar days, such as UTC or Europe/Berlin")
+	cronCmd.Flags().Bool("times", false, "Show the times jobs fire instead of their number of runs")
+
+	rootCmd.AddCommand(cronCmd)
+}
+
+// readScheduleFile reads the jobs of the file at path, or of standard input when path is -
+func readScheduleFile(path string, read func(r io.Reader) ([]schedule.Job, error)) ([]schedule.Job, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(sources.ExpandHome(path))
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		r = file
+	}
+
+	jobs, err := read(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return jobs, nil
+}
+
+// readJobs reads the jobs of the expressions and files named by the flags, in that order
+func readJobs(cmd *cobra.Command) ([]schedule.Job, error) {
+	exprs, _ := cmd.Flags().GetStringArray("expr")
+	crontabs, _ := cmd.Flags().GetStringArray("crontab")
+	timers, _ := cmd.Flags().GetStringArray("timer")
+	cronJobs, _ := cmd.Flags().GetStringArray("cronjob")
+
+	var jobs []schedule.Job
+	for _, expr := range exprs {
+		cron, err := schedule.ParseCron(expr)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, schedule.Job{Name: expr, Schedule: cron})
+	}
+	for _, path := range crontabs {
+		found, err := readScheduleFile(path, sources.ReadCrontab)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, found...)
+	}
+	for _, path := range timers {
+		name := strings.TrimSuffix(filepath.Base(path), ".timer")
+		found, err := readScheduleFile(path, func(r io.Reader) ([]schedule.Job, error) {
+			return sources.ReadTimer(r, name)
+		})
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, found...)
+	}
+	for _, path := range cronJobs {
+		found, err := readScheduleFile(path, sources.ReadCronJobs)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, found...)
+	}
+
+	if len(exprs)+len(crontabs)+len(timers)+len(cronJobs) == 0 {
+		return nil, errors.New("no schedule given: use --expr, --crontab, --timer or --cronjob")
+	}
+	return jobs, nil
+}
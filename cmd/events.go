@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/andre-a-alves/mdcal/cmd/calendar"
+	"github.com/andre-a-alves/mdcal/cmd/sources"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// addEventSource adds the single and recurring events of the events file named by the flags to the days of the calendar
+func addEventSource(cmd *cobra.Command, options *calendar.Options) error {
+	path, _ := cmd.Flags().GetString("events")
+	if path == "" {
+		return nil
+	}
+
+	file, err := os.Open(sources.ExpandHome(path))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	events, err := sources.ReadEvents(file)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	options.AddAnnotator(0, sources.EventAnnotator(events))
+	return nil
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/andre-a-alves/mdcal/cmd/utils"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseHolidays parses --holiday values of the form YYYY-MM-DD or YYYY-MM-DD=Name into a map keyed by date
+func parseHolidays(values []string) (map[string]string, error) {
+	holidays := make(map[string]string, len(values))
+	for _, value := range values {
+		date, name, _ := strings.Cut(value, "=")
+		parsed, err := time.Parse(time.DateOnly, strings.TrimSpace(date))
+		if err != nil {
+			return nil, fmt.Errorf("invalid holiday %q: expected YYYY-MM-DD or YYYY-MM-DD=Name", value)
+		}
+		holidays[parsed.Format(time.DateOnly)] = strings.TrimSpace(name)
+	}
+	return holidays, nil
+}
+
+// parseHours parses an --hours value of the form HH:MM-HH:MM into offsets from midnight
+func parseHours(value string) (time.Duration, time.Duration, error) {
+	startValue, endValue, found := strings.Cut(value, "-")
+	if !found {
+		return 0, 0, fmt.Errorf("invalid hours %q: expected HH:MM-HH:MM", value)
+	}
+
+	start, errStart := parseClock(startValue)
+	end, errEnd := parseClock(endValue)
+	if errStart != nil || errEnd != nil {
+		return 0, 0, fmt.Errorf("invalid hours %q: expected HH:MM-HH:MM", value)
+	}
+
+	return start, end, nil
+}
+
+// parseClock parses a HH:MM clock time into an offset from midnight, accepting 24:00 as the end of the day
+func parseClock(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "24:00" {
+		return 24 * time.Hour, nil
+	}
+
+	clock, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute, nil
+}
+
+// parseBuckets parses a --buckets value, either "quantile" or comma separated thresholds such as 1,5,10.
+// Quantile bucketing is returned as nil thresholds.
+func parseBuckets(value string) ([]float64, error) {
+	if strings.EqualFold(strings.TrimSpace(value), "quantile") {
+		return nil, nil
+	}
+
+	var thresholds []float64
+	for _, field := range strings.Split(value, ",") {
+		threshold, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid buckets %q: expected quantile or thresholds such as 1,5,10", value)
+		}
+		thresholds = append(thresholds, threshold)
+	}
+	return thresholds, nil
+}
+
+// parseLength parses the value of a length flag such as --sprint-length, like 2w or 10d, into a number of days,
+// reporting whether it was given in weeks
+func parseLength(flag string, value string) (int, bool, error) {
+	number := strings.ToLower(strings.TrimSpace(value))
+	unit := 1
+	switch {
+	case strings.HasSuffix(number, "w"):
+		unit = 7
+		number = strings.TrimSuffix(number, "w")
+	case strings.HasSuffix(number, "d"):
+		number = strings.TrimSuffix(number, "d")
+	}
+
+	n, err := strconv.Atoi(number)
+	if err != nil || n < 1 {
+		return 0, false, fmt.Errorf("invalid %s %q: expected a number of weeks or days, such as 2w or 10d", flag, value)
+	}
+	return n * unit, unit == 7, nil
+}
+
+// parsePattern parses a --pattern value such as 2-2-3 into the lengths of successive turns in days
+func parsePattern(value string) ([]int, error) {
+	var lengths []int
+	for _, field := range strings.Split(value, "-") {
+		length, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || length < 1 {
+			return nil, fmt.Errorf("invalid pattern %q: expected turn lengths in days, such as 2-2-3", value)
+		}
+		lengths = append(lengths, length)
+	}
+	return lengths, nil
+}
+
+// parseSkips parses --skip values of the form YYYY-MM-DD or YYYY-MM-DD..YYYY-MM-DD into a set of days
+func parseSkips(values []string) (map[string]bool, error) {
+	skips := make(map[string]bool)
+	for _, value := range values {
+		days, err := utils.ParseDays(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid skip %q: expected YYYY-MM-DD or YYYY-MM-DD..YYYY-MM-DD", value)
+		}
+		for _, day := range days {
+			skips[day] = true
+		}
+	}
+	return skips, nil
+}
+
+// parseOverrides parses --override values of the form DAYS=person or DAYS/shift=person, where DAYS is a
+// YYYY-MM-DD date or a YYYY-MM-DD..YYYY-MM-DD range, into the people taking over keyed by day or by day/shift
+func parseOverrides(values []string, shifts []string) (map[string]string, error) {
+	overrides := make(map[string]string)
+	for _, value := range values {
+		target, person, found := strings.Cut(value, "=")
+		person = strings.TrimSpace(person)
+		dates, shift, hasShift := strings.Cut(target, "/")
+		shift = strings.TrimSpace(shift)
+		days, err := utils.ParseDays(dates)
+		if !found || person == "" || err != nil || (hasShift && shift == "") {
+			return nil, fmt.Errorf("invalid override %q: expected YYYY-MM-DD=person, YYYY-MM-DD..YYYY-MM-DD=person or YYYY-MM-DD/shift=person", value)
+		}
+		if hasShift && len(shifts) == 0 {
+			return nil, fmt.Errorf("invalid override %q: no --shifts given", value)
+		}
+		if hasShift && !slices.Contains(shifts, shift) {
+			return nil, fmt.Errorf("invalid override %q: unknown shift %q, expected one of %s", value, shift, strings.Join(shifts, ", "))
+		}
+
+		for _, day := range days {
+			if hasShift {
+				day += "/" + shift
+			}
+			overrides[day] = person
+		}
+	}
+	return overrides, nil
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"github.com/andre-a-alves/mdcal/cmd/calendar"
+	"github.com/andre-a-alves/mdcal/cmd/sources"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var gitCmd = &cobra.Command{
+	Use:   "git [year] [month] [endMonth|endYear endMonth]",
+	Short: "Generate a calendar of the commit activity of a git repository",
+	Long: `git annotates each day with the number of commits of a local repository, by committer date,
+optionally marking the days its tags were created. The history is read with git log, without any network access.
+Examples:
+  mdcal git 2025 3                                - Commits to the repository in the current directory in March 2025
+  mdcal git --repo ~/src/app --author alice 2025  - Commits by alice in 2025
+  mdcal git --tags --heatmap --period year 2025   - Contribution graph of 2025 with releases marked`,
+	Args: cobra.MaximumNArgs(4),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		options, err := initOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		if err := processCommandLineArgs(args, &options); err != nil {
+			return err
+		}
+
+		repo, _ := cmd.Flags().GetString("repo")
+		authors, _ := cmd.Flags().GetStringArray("author")
+		showTags, _ := cmd.Flags().GetBool("tags")
+		showHeatmap, _ := cmd.Flags().GetBool("heatmap")
+
+		// Surface invalid options before reading the history
+		if err := options.Validate(); err != nil {
+			return err
+		}
+		start, end := options.DateRange()
+
+		counts, err := sources.GitCommitCounts(repo, authors, start, end)
+		if err != nil {
+			return err
+		}
+		if showTags {
+			tags, err := sources.GitTags(repo, start, end)
+			if err != nil {
+				return err
+			}
+			options.AddAnnotator(10, calendar.Events(tags))
+		}
+
+		if !showHeatmap {
+			options.AddAnnotator(0, calendar.Events(sources.CommitAnnotations(counts)))
+			return writeCalendar(cmd, options)
+		}
+
+		heatmap, err := initHeatmapFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		// Days without commits count as zero up to today, and only later days have no data
+		heatmap.Values = make(map[string]float64)
+		for date := start; !date.After(end) && !date.After(time.Now()); date = date.AddDate(0, 0, 1) {
+			heatmap.Values[date.Format(time.DateOnly)] = 0
+		}
+		for date, count := range counts {
+			heatmap.Values[date] = float64(count)
+		}
+		return writeHeatmap(cmd, options, heatmap)
+	},
+}
+
+func init() {
+	gitCmd.Flags().String("repo", ".", "Path of the local git repository")
+	gitCmd.Flags().StringArray("author", nil, "Only count commits whose author name or email matches (repeatable)")
+	gitCmd.Flags().Bool("tags", false, "Mark the days tags were created")
+	gitCmd.Flags().Bool("heatmap", false, "Shade the days by their number of commits instead of annotating them")
+	addHeatmapFlags(gitCmd)
+
+	rootCmd.AddCommand(gitCmd)
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/andre-a-alves/mdcal/cmd/calendar"
+	"github.com/andre-a-alves/mdcal/cmd/sources"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var heatmapCmd = &cobra.Command{
+	Use:   "heatmap --data values.csv [year] [month] [endMonth|endYear endMonth]",
+	Short: "Generate a heatmap calendar from dated values",
+	Long: `heatmap shades each day of the calendar by the value read for it from a CSV file of date,value records.
+Days missing from the file are shown as no data, distinct from days with a value of zero.
+Examples:
+  mdcal heatmap --data deploys.csv 2025 3              - Heatmap of March 2025
+  mdcal heatmap --data deploys.csv --period year 2025  - Contribution graph of 2025
+  mdcal heatmap --data incidents.csv --buckets 1,3,5 --glyphs squares 2025 1 3`,
+	Args: cobra.MaximumNArgs(4),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		options, err := initOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		if err := processCommandLineArgs(args, &options); err != nil {
+			return err
+		}
+
+		heatmap, err := initHeatmapFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		dataPath, _ := cmd.Flags().GetString("data")
+		if heatmap.Values, err = readValues(dataPath); err != nil {
+			return err
+		}
+
+		return writeHeatmap(cmd, options, heatmap)
+	},
+}
+
+func init() {
+	heatmapCmd.Flags().String("data", "", "CSV file of date,value records")
+	_ = heatmapCmd.MarkFlagRequired("data")
+	addHeatmapFlags(heatmapCmd)
+
+	rootCmd.AddCommand(heatmapCmd)
+}
+
+// addHeatmapFlags defines the flags that shape a heatmap on cmd
+func addHeatmapFlags(cmd *cobra.Command) {
+	cmd.Flags().String("glyphs", "blocks", "Shades of the heatmap: "+strings.Join(calendar.HeatmapGlyphNames(), ", "))
+	cmd.Flags().String("buckets", "quantile", "Bucketing of the values: quantile, or increasing thresholds such as 1,5,10")
+	cmd.Flags().String("period", "month", "Table per month, or per year with weekdays as rows and weeks as columns")
+}
+
+// initHeatmapFromFlags initializes the heatmap settings, without values, from command-line flags
+func initHeatmapFromFlags(cmd *cobra.Command) (calendar.Heatmap, error) {
+	glyphs, _ := cmd.Flags().GetString("glyphs")
+	buckets, _ := cmd.Flags().GetString("buckets")
+	period, _ := cmd.Flags().GetString("period")
+
+	thresholds, err := parseBuckets(buckets)
+	if err != nil {
+		return calendar.Heatmap{}, err
+	}
+
+	return calendar.Heatmap{Glyphs: glyphs, Thresholds: thresholds, Period: period}, nil
+}
+
+// readValues reads the date,value records of the CSV file at path
+func readValues(path string) (map[string]float64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	values, err := sources.ReadValues(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return values, nil
+}
+
+// writeHeatmap streams the heatmap to the output as it is generated
+func writeHeatmap(cmd *cobra.Command, options calendar.Options, heatmap calendar.Heatmap) error {
+	return writeOutput(cmd, func(w io.Writer) error {
+		return calendar.WriteHeatmap(w, options, heatmap)
+	})
+}
@@ -0,0 +1,230 @@
+package recurrence
+
+import (
+	"slices"
+	"time"
+)
+
+// Recurrence is a series of days: a single day, or the days of a rule starting on Start
+type Recurrence struct {
+	Start   time.Time
+	Rule    *Rule       // nil for a single day
+	Exdates []time.Time // days left out of the series
+}
+
+// day returns the UTC midnight of the day of t
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// daysIn returns the number of days of the month
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// nthWeekday returns the nth weekday between first and last, counting from last when n is negative,
+// reporting whether there is one
+func nthWeekday(first time.Time, last time.Time, n int, weekday time.Weekday) (time.Time, bool) {
+	var date time.Time
+	if n > 0 {
+		date = first.AddDate(0, 0, (int(weekday)-int(first.Weekday())+7)%7+7*(n-1))
+	} else {
+		date = last.AddDate(0, 0, -((int(last.Weekday())-int(weekday)+7)%7 + 7*(-n-1)))
+	}
+	return date, !date.Before(first) && !date.After(last)
+}
+
+// weekdays returns the days between first and last selected by the BYDAY entries, with ordinals counted
+// within that span
+func (r Rule) weekdays(first time.Time, last time.Time) []time.Time {
+	var days []time.Time
+	for _, wd := range r.ByDay {
+		if wd.N != 0 {
+			if date, ok := nthWeekday(first, last, wd.N, wd.Weekday); ok {
+				days = append(days, date)
+			}
+			continue
+		}
+		for date, _ := nthWeekday(first, last, 1, wd.Weekday); !date.After(last); date = date.AddDate(0, 0, 7) {
+			days = append(days, date)
+		}
+	}
+	return days
+}
+
+// hasWeekday reports whether the weekday of date is one of the BYDAY weekdays, ignoring their ordinals
+func (r Rule) hasWeekday(date time.Time) bool {
+	return slices.ContainsFunc(r.ByDay, func(wd WeekdayNum) bool { return wd.Weekday == date.Weekday() })
+}
+
+// hasMonthDay reports whether date is one of the BYMONTHDAY days of its month
+func (r Rule) hasMonthDay(date time.Time) bool {
+	n := daysIn(date.Year(), date.Month())
+	return slices.ContainsFunc(r.ByMonthDay, func(d int) bool { return d == date.Day() || n+d+1 == date.Day() })
+}
+
+// monthDays returns the days of the month selected by the rule, falling back to the day of the month of start
+func (r Rule) monthDays(start time.Time, year int, month time.Month) []time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1)
+
+	var days []time.Time
+	switch {
+	case len(r.ByMonthDay) > 0:
+		for date := first; !date.After(last); date = date.AddDate(0, 0, 1) {
+			if r.hasMonthDay(date) && (len(r.ByDay) == 0 || r.hasWeekday(date)) {
+				days = append(days, date)
+			}
+		}
+	case len(r.ByDay) > 0:
+		days = r.weekdays(first, last)
+	case start.Day() <= last.Day():
+		days = append(days, time.Date(year, month, start.Day(), 0, 0, 0, 0, time.UTC))
+	}
+	return days
+}
+
+// periodStart returns the first day of the period n periods after the one of start
+func (r Rule) periodStart(start time.Time, n int) time.Time {
+	switch r.Freq {
+	case Weekly:
+		weekStart := start.AddDate(0, 0, -((int(start.Weekday()) - int(r.WeekStart) + 7) % 7))
+		return weekStart.AddDate(0, 0, 7*n)
+	case Monthly:
+		return time.Date(start.Year(), start.Month()+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+	case Yearly:
+		return time.Date(start.Year()+n, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return start.AddDate(0, 0, n)
+}
+
+// periodsBefore returns the number of whole intervals of periods between the period of start and that of from,
+// so that expansion can begin at the period of from
+func (r Rule) periodsBefore(start time.Time, from time.Time) int {
+	var n int
+	switch r.Freq {
+	case Weekly:
+		n = int(r.periodStart(from, 0).Sub(r.periodStart(start, 0)).Hours()/24) / 7
+	case Monthly:
+		n = (from.Year()-start.Year())*12 + int(from.Month()) - int(start.Month())
+	case Yearly:
+		n = from.Year() - start.Year()
+	default:
+		n = int(from.Sub(start).Hours() / 24)
+	}
+	if n <= 0 {
+		return 0
+	}
+	return n - n%r.Interval
+}
+
+// candidates returns the sorted days of the period starting on periodStart selected by the rule
+func (r Rule) candidates(start time.Time, periodStart time.Time) []time.Time {
+	var days []time.Time
+	switch r.Freq {
+	case Daily:
+		if (len(r.ByDay) == 0 || r.hasWeekday(periodStart)) && (len(r.ByMonthDay) == 0 || r.hasMonthDay(periodStart)) {
+			days = append(days, periodStart)
+		}
+	case Weekly:
+		if len(r.ByDay) == 0 {
+			days = append(days, periodStart.AddDate(0, 0, (int(start.Weekday())-int(r.WeekStart)+7)%7))
+		}
+		for i := 0; i < 7 && len(r.ByDay) > 0; i++ {
+			if date := periodStart.AddDate(0, 0, i); r.hasWeekday(date) {
+				days = append(days, date)
+			}
+		}
+	case Monthly:
+		days = r.monthDays(start, periodStart.Year(), periodStart.Month())
+	case Yearly:
+		year := periodStart.Year()
+		switch {
+		case len(r.ByMonth) > 0:
+			for _, month := range r.ByMonth {
+				days = append(days, r.monthDays(start, year, month)...)
+			}
+		case len(r.ByMonthDay) > 0:
+			for month := time.January; month <= time.December; month++ {
+				days = append(days, r.monthDays(start, year, month)...)
+			}
+		case len(r.ByDay) > 0:
+			// Ordinals of weekdays without BYMONTH count within the year, such as 20MO for the 20th Monday
+			days = r.weekdays(periodStart, periodStart.AddDate(1, 0, -1))
+		default:
+			days = r.monthDays(start, year, start.Month())
+		}
+	}
+
+	if len(r.ByMonth) > 0 {
+		days = slices.DeleteFunc(days, func(date time.Time) bool { return !slices.Contains(r.ByMonth, date.Month()) })
+	}
+	slices.SortFunc(days, func(a, b time.Time) int { return a.Compare(b) })
+	days = slices.CompactFunc(days, func(a, b time.Time) bool { return a.Equal(b) })
+
+	if len(r.BySetPos) == 0 {
+		return days
+	}
+	var selected []time.Time
+	for _, pos := range r.BySetPos {
+		i := pos - 1
+		if pos < 0 {
+			i = len(days) + pos
+		}
+		if i >= 0 && i < len(days) {
+			selected = append(selected, days[i])
+		}
+	}
+	slices.SortFunc(selected, func(a, b time.Time) int { return a.Compare(b) })
+	return slices.CompactFunc(selected, func(a, b time.Time) bool { return a.Equal(b) })
+}
+
+// excluded reports whether date is one of the days left out of the series
+func (r Recurrence) excluded(date time.Time) bool {
+	return slices.ContainsFunc(r.Exdates, func(exdate time.Time) bool { return day(exdate).Equal(date) })
+}
+
+// Between returns the days of the series between from and to, inclusive. Only the periods of the rule from the one
+// of from onwards are expanded, unless the rule is limited by COUNT, which counts its occurrences from Start.
+// Days of the rule before Start, and days left out by Exdates, are not part of the series.
+func (r Recurrence) Between(from time.Time, to time.Time) []time.Time {
+	start, from, to := day(r.Start), day(from), day(to)
+	if r.Rule == nil {
+		if start.Before(from) || start.After(to) || r.excluded(start) {
+			return nil
+		}
+		return []time.Time{start}
+	}
+
+	rule := *r.Rule
+	last := to
+	if !rule.Until.IsZero() && rule.Until.Before(last) {
+		last = day(rule.Until)
+	}
+
+	n := 0
+	if rule.Count == 0 {
+		n = rule.periodsBefore(start, from)
+	}
+
+	var days []time.Time
+	for count := 0; ; n += rule.Interval {
+		if rule.periodStart(start, n).After(last) {
+			return days
+		}
+		for _, date := range rule.candidates(start, rule.periodStart(start, n)) {
+			if date.Before(start) {
+				continue
+			}
+			if date.After(last) {
+				return days
+			}
+			if !date.Before(from) && !r.excluded(date) {
+				days = append(days, date)
+			}
+			if count++; rule.Count > 0 && count >= rule.Count {
+				return days
+			}
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package recurrence
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// dates parses a space separated list of YYYY-MM-DD days
+func dates(t *testing.T, values string) []time.Time {
+	t.Helper()
+	var days []time.Time
+	for _, value := range strings.Fields(values) {
+		date, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		days = append(days, date)
+	}
+	return days
+}
+
+func TestRecurrenceBetween(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    string
+		rule     string
+		exdates  string
+		from     string
+		to       string
+		expected string
+	}{
+		{
+			name:     "Single day",
+			start:    "2025-03-14",
+			from:     "2025-03-01",
+			to:       "2025-03-31",
+			expected: "2025-03-14",
+		},
+		{
+			name:     "Every other day",
+			start:    "2025-03-01",
+			rule:     "FREQ=DAILY;INTERVAL=2;COUNT=4",
+			from:     "2025-03-01",
+			to:       "2025-03-31",
+			expected: "2025-03-01 2025-03-03 2025-03-05 2025-03-07",
+		},
+		{
+			name:     "Second Tuesday of the month",
+			start:    "2025-01-01",
+			rule:     "RRULE:FREQ=MONTHLY;BYDAY=2TU",
+			from:     "2025-01-01",
+			to:       "2025-04-30",
+			expected: "2025-01-14 2025-02-11 2025-03-11 2025-04-08",
+		},
+		{
+			name:     "Last weekday of the month",
+			start:    "2025-01-01",
+			rule:     "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			from:     "2025-05-01",
+			to:       "2025-08-31",
+			expected: "2025-05-30 2025-06-30 2025-07-31 2025-08-29",
+		},
+		{
+			name:     "Every other Friday until June, with an exception",
+			start:    "2025-05-02",
+			rule:     "FREQ=WEEKLY;INTERVAL=2;BYDAY=FR;UNTIL=20250620T000000Z",
+			exdates:  "2025-05-30",
+			from:     "2025-01-01",
+			to:       "2025-12-31",
+			expected: "2025-05-02 2025-05-16 2025-06-13",
+		},
+		{
+			name:     "Interval kept when starting past the first periods",
+			start:    "2025-01-03",
+			rule:     "FREQ=WEEKLY;INTERVAL=2",
+			from:     "2025-03-01",
+			to:       "2025-03-31",
+			expected: "2025-03-14 2025-03-28",
+		},
+		{
+			name:     "Count counted from the start",
+			start:    "2025-01-06",
+			rule:     "FREQ=WEEKLY;BYDAY=MO,TH;COUNT=5",
+			from:     "2025-01-13",
+			to:       "2025-12-31",
+			expected: "2025-01-13 2025-01-16 2025-01-20",
+		},
+		{
+			name:     "Last day of the month",
+			start:    "2025-01-31",
+			rule:     "FREQ=MONTHLY;BYMONTHDAY=-1",
+			from:     "2025-01-01",
+			to:       "2025-04-30",
+			expected: "2025-01-31 2025-02-28 2025-03-31 2025-04-30",
+		},
+		{
+			name:     "Monthly on a day some months lack",
+			start:    "2025-01-31",
+			rule:     "FREQ=MONTHLY",
+			from:     "2025-01-01",
+			to:       "2025-05-31",
+			expected: "2025-01-31 2025-03-31 2025-05-31",
+		},
+		{
+			name:     "Friday the 13th",
+			start:    "2025-01-01",
+			rule:     "FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13",
+			from:     "2025-01-01",
+			to:       "2025-12-31",
+			expected: "2025-06-13",
+		},
+		{
+			name:     "Last Monday of May",
+			start:    "2020-05-25",
+			rule:     "FREQ=YEARLY;BYMONTH=5;BYDAY=-1MO",
+			from:     "2025-01-01",
+			to:       "2026-12-31",
+			expected: "2025-05-26 2026-05-25",
+		},
+		{
+			name:     "Yearly on the day of the start",
+			start:    "2024-02-29",
+			rule:     "FREQ=YEARLY",
+			from:     "2024-01-01",
+			to:       "2028-12-31",
+			expected: "2024-02-29 2028-02-29",
+		},
+		{
+			name:     "Days of the rule before the start are skipped",
+			start:    "2025-03-12",
+			rule:     "FREQ=WEEKLY;BYDAY=MO,FR",
+			from:     "2025-03-01",
+			to:       "2025-03-18",
+			expected: "2025-03-14 2025-03-17",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recurrence := Recurrence{Start: dates(t, tt.start)[0], Exdates: dates(t, tt.exdates)}
+			if tt.rule != "" {
+				rule, err := ParseRule(tt.rule)
+				if err != nil {
+					t.Fatalf("ParseRule(%q) unexpected error: %v", tt.rule, err)
+				}
+				recurrence.Rule = &rule
+			}
+
+			actual := recurrence.Between(dates(t, tt.from)[0], dates(t, tt.to)[0])
+			if diff := cmp.Diff(dates(t, tt.expected), actual); diff != "" {
+				t.Errorf("Between() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    Rule
+		expectedErr string
+	}{
+		{
+			name:  "All parts",
+			value: "FREQ=YEARLY;INTERVAL=2;BYMONTH=3,9;BYDAY=-1FR,MO;BYMONTHDAY=1,-1;BYSETPOS=1;UNTIL=2030-12-31;WKST=SU",
+			expected: Rule{
+				Freq: Yearly, Interval: 2, ByMonth: []time.Month{time.March, time.September},
+				ByDay:      []WeekdayNum{{N: -1, Weekday: time.Friday}, {Weekday: time.Monday}},
+				ByMonthDay: []int{1, -1}, BySetPos: []int{1},
+				Until:     time.Date(2030, time.December, 31, 0, 0, 0, 0, time.UTC),
+				WeekStart: time.Sunday,
+			},
+		},
+		{name: "Missing FREQ", value: "INTERVAL=2", expectedErr: "no FREQ"},
+		{name: "Unknown FREQ", value: "FREQ=HOURLY", expectedErr: "unsupported FREQ"},
+		{name: "Invalid BYDAY", value: "FREQ=MONTHLY;BYDAY=2XX", expectedErr: "invalid BYDAY"},
+		{name: "Day of month out of range", value: "FREQ=MONTHLY;BYMONTHDAY=32", expectedErr: "invalid BYMONTHDAY"},
+		{name: "COUNT with UNTIL", value: "FREQ=DAILY;COUNT=3;UNTIL=20250101", expectedErr: "both COUNT and UNTIL"},
+		{name: "Unsupported part", value: "FREQ=DAILY;BYHOUR=9", expectedErr: "unsupported rule part BYHOUR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := ParseRule(tt.value)
+			if tt.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+					t.Fatalf("ParseRule(%q) error = %v, want %q", tt.value, err, tt.expectedErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRule(%q) unexpected error: %v", tt.value, err)
+			}
+			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+				t.Errorf("ParseRule(%q) mismatch (-want +got):\n%s", tt.value, diff)
+			}
+		})
+	}
+}
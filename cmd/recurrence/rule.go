@@ -0,0 +1,156 @@
+package recurrence
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the FREQ of a rule, the period its occurrences are expanded from
+type Frequency int
+
+const (
+	Daily Frequency = iota
+	Weekly
+	Monthly
+	Yearly
+)
+
+// frequencies maps the FREQ values of RFC 5545 to their Frequency
+var frequencies = map[string]Frequency{"DAILY": Daily, "WEEKLY": Weekly, "MONTHLY": Monthly, "YEARLY": Yearly}
+
+// weekdayCodes maps the two letter weekday codes of RFC 5545 to their weekday
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// byDayPattern matches a BYDAY entry, such as TU, 2TU or -1FR
+var byDayPattern = regexp.MustCompile(`^([+-]?\d{1,2})?(SU|MO|TU|WE|TH|FR|SA)$`)
+
+// WeekdayNum is a BYDAY entry: a weekday, and for monthly and yearly rules its ordinal within the period,
+// such as 2 for the second Tuesday or -1 for the last Friday. N is zero for every such weekday of the period.
+type WeekdayNum struct {
+	N       int
+	Weekday time.Weekday
+}
+
+// Rule is a recurrence rule in the RRULE form of RFC 5545, limited to whole days
+type Rule struct {
+	Freq       Frequency
+	Interval   int // number of periods between occurrences, at least 1
+	ByDay      []WeekdayNum
+	ByMonthDay []int // days of the month, negative from its end, such as -1 for the last day
+	ByMonth    []time.Month
+	BySetPos   []int        // positions within the occurrences of each period, negative from its end
+	Until      time.Time    // last possible day; zero when the rule is not limited by date
+	Count      int          // number of occurrences; zero when the rule is not limited by count
+	WeekStart  time.Weekday // first day of the week of weekly rules, Monday by default
+}
+
+// ParseDate parses a day written as YYYY-MM-DD, YYYYMMDD or an RFC 5545 date-time such as 20250630T235959Z
+func ParseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if len(value) > 8 && value[8] == 'T' {
+		value = value[:8]
+	}
+	for _, layout := range []string{time.DateOnly, "20060102"} {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q: expected YYYY-MM-DD or YYYYMMDD", value)
+}
+
+// parseInts parses a comma separated list of integers between min and max, leaving out zero
+func parseInts(key string, value string, min int, max int) ([]int, error) {
+	var values []int
+	for _, part := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || n == 0 || n < min || n > max {
+			return nil, fmt.Errorf("invalid %s %q", key, part)
+		}
+		values = append(values, n)
+	}
+	return values, nil
+}
+
+// ParseRule parses an RRULE value such as FREQ=MONTHLY;BYDAY=2TU, with or without its RRULE: prefix.
+// FREQ, INTERVAL, BYDAY, BYMONTHDAY, BYMONTH, BYSETPOS, UNTIL, COUNT and WKST are supported.
+func ParseRule(value string) (Rule, error) {
+	rule := Rule{Freq: -1, Interval: 1, WeekStart: time.Monday}
+
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	for _, part := range strings.Split(value, ";") {
+		if part == "" {
+			continue
+		}
+		key, val, found := strings.Cut(part, "=")
+		if !found {
+			return Rule{}, fmt.Errorf("invalid rule part %q: expected NAME=VALUE", part)
+		}
+
+		var err error
+		key, val = strings.ToUpper(strings.TrimSpace(key)), strings.ToUpper(strings.TrimSpace(val))
+		switch key {
+		case "FREQ":
+			freq, ok := frequencies[val]
+			if !ok {
+				return Rule{}, fmt.Errorf("unsupported FREQ %q: expected DAILY, WEEKLY, MONTHLY or YEARLY", val)
+			}
+			rule.Freq = freq
+		case "INTERVAL", "COUNT":
+			n, convErr := strconv.Atoi(val)
+			if convErr != nil || n < 1 {
+				return Rule{}, fmt.Errorf("invalid %s %q: expected a positive number", key, val)
+			}
+			if key == "INTERVAL" {
+				rule.Interval = n
+			} else {
+				rule.Count = n
+			}
+		case "UNTIL":
+			rule.Until, err = ParseDate(val)
+		case "BYDAY":
+			for _, entry := range strings.Split(val, ",") {
+				match := byDayPattern.FindStringSubmatch(strings.TrimSpace(entry))
+				if match == nil {
+					return Rule{}, fmt.Errorf("invalid BYDAY %q: expected a weekday such as TU, 2TU or -1FR", entry)
+				}
+				n, _ := strconv.Atoi(match[1])
+				rule.ByDay = append(rule.ByDay, WeekdayNum{N: n, Weekday: weekdayCodes[match[2]]})
+			}
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseInts(key, val, -31, 31)
+		case "BYMONTH":
+			var months []int
+			months, err = parseInts(key, val, 1, 12)
+			for _, m := range months {
+				rule.ByMonth = append(rule.ByMonth, time.Month(m))
+			}
+		case "BYSETPOS":
+			rule.BySetPos, err = parseInts(key, val, -366, 366)
+		case "WKST":
+			weekday, ok := weekdayCodes[val]
+			if !ok {
+				return Rule{}, fmt.Errorf("invalid WKST %q: expected a weekday such as MO", val)
+			}
+			rule.WeekStart = weekday
+		default:
+			return Rule{}, fmt.Errorf("unsupported rule part %s", key)
+		}
+		if err != nil {
+			return Rule{}, err
+		}
+	}
+
+	if rule.Freq < 0 {
+		return Rule{}, fmt.Errorf("rule %q has no FREQ", value)
+	}
+	if rule.Count > 0 && !rule.Until.IsZero() {
+		return Rule{}, fmt.Errorf("rule %q has both COUNT and UNTIL", value)
+	}
+	return rule, nil
+}
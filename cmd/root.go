@@ -1,25 +1,37 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
 	"github.com/andre-a-alves/mdcal/cmd/calendar"
 	"github.com/andre-a-alves/mdcal/cmd/interactive"
-	"github.com/andre-a-alves/mdcal/cmd/utils"
+	"github.com/andre-a-alves/mdcal/cmd/sources"
+	"io"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
 
+// holidayPriority is the priority of the --holiday annotator, which runs first so holiday names lead the annotations
+// of the day
+const holidayPriority = -100
+
 var rootCmd = &cobra.Command{
 	Use:   "mdcal",
 	Short: "mdcal generates a markdown calendar.",
 	Long:  "A customized markdown calendar generator that can either be run interactively or with option flags.",
+	// Errors are printed once by Execute, without the usage text
+	SilenceErrors: true,
+	SilenceUsage:  true,
 }
 
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Println(err)
+		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
 	}
 }
@@ -35,6 +47,8 @@ Examples:
   mdcal 2025 12 2026 1 - Generate calendar for December 2025 through January 2026
 
 If no arguments are provided, it runs in interactive mode.`
+	// Accept the date arguments even though the command has subcommands
+	rootCmd.Args = cobra.MaximumNArgs(4)
 
 	// Define flags
 	rootCmd.PersistentFlags().StringP("start", "s", "monday", "First day of the week (monday/mon)")
@@ -44,137 +58,329 @@ If no arguments are provided, it runs in interactive mode.`
 	rootCmd.PersistentFlags().BoolP("version", "v", false, "Print version information")
 	rootCmd.PersistentFlags().BoolP("short", "S", false, "Display calendar with short day names (Mon, Tue, etc.)")
 	rootCmd.PersistentFlags().StringP("justify", "j", "left", "Cell justification: left, center, or right")
+	rootCmd.PersistentFlags().StringP("format", "f", "markdown", "Output format: "+strings.Join(calendar.RendererNames(), ", "))
+	rootCmd.PersistentFlags().StringP("layout", "l", calendar.GridLayout, "Calendar layout: "+strings.Join(calendar.LayoutNames(), ", "))
+	rootCmd.PersistentFlags().BoolP("transpose", "t", false, "Show weekdays as rows and weeks as columns")
+	rootCmd.PersistentFlags().Int("columns", 1, "Number of months placed side by side in the grid layout")
+	rootCmd.PersistentFlags().Bool("checkboxes", false, "Add checkboxes to the agenda notes and habits cells")
+	rootCmd.PersistentFlags().String("hours", "08:00-18:00", "Hours covered by the week layout, as HH:MM-HH:MM")
+	rootCmd.PersistentFlags().Duration("slot", 30*time.Minute, "Length of each time slot of the week layout")
+	rootCmd.PersistentFlags().StringArray("habit", nil, "Habit tracked in its own column of the habits layout (repeatable)")
+	rootCmd.PersistentFlags().Bool("align-weekdays", false, "Align the linear layout by weekday (37 columns)")
+	rootCmd.PersistentFlags().StringArray("holiday", nil, "Mark a holiday, as YYYY-MM-DD or YYYY-MM-DD=Name (repeatable)")
+	rootCmd.PersistentFlags().StringP("output", "o", "", "Write the calendar to this file instead of standard output")
+	rootCmd.PersistentFlags().String("journal", "", "Directory of daily notes to link the days to")
+	rootCmd.PersistentFlags().String("journal-pattern", "2006-01-02.md", "Path of the note of a day within the journal, as a Go time layout")
+	rootCmd.PersistentFlags().String("journal-missing", "", "Mark appended to days without a note in the journal")
+	rootCmd.PersistentFlags().String("day-link", "", "Go template turning each day into a link, such as [[{{.Date.Format \"2006-01-02\"}}]]")
+	rootCmd.PersistentFlags().String("sprint-start", "", "First day of the first sprint, as YYYY-MM-DD, to label weeks with their sprint")
+	rootCmd.PersistentFlags().String("sprint-length", "2w", "Length of every sprint, in weeks or days such as 2w or 10d")
+	rootCmd.PersistentFlags().String("sprint-name", "Sprint {{.N}}", "Go template naming each sprint from its number .N and its .Start and .End days")
+	rootCmd.PersistentFlags().String("events", "", "File of events, one per line as DATE [RRULE:rule] [EXDATE:dates] text")
+	rootCmd.PersistentFlags().String("todotxt", "", "todo.txt file whose tasks with a due:YYYY-MM-DD date are added to their days")
+	rootCmd.PersistentFlags().String("taskwarrior", "", "JSON file written by task export whose tasks with a due date are added to their days")
+	rootCmd.PersistentFlags().StringArray("project", nil, "Only show tasks of this project or its subprojects (repeatable)")
+	rootCmd.PersistentFlags().StringArray("context", nil, "Only show tasks with this context or Taskwarrior tag (repeatable)")
+	rootCmd.PersistentFlags().String("org", "", "Org file whose SCHEDULED and DEADLINE headlines are added to their days")
+	rootCmd.PersistentFlags().StringArray("org-keyword", nil, "Only show Org headlines with this TODO keyword (repeatable)")
+	rootCmd.PersistentFlags().StringArray("org-tag", nil, "Only show Org headlines with this tag, including inherited ones (repeatable)")
+	rootCmd.PersistentFlags().String("vcf", "", "vCard file whose birthdays and anniversaries are added to their days every year")
+	rootCmd.PersistentFlags().String("feb29", "feb28", "Day of February 29 birthdays in other years: feb28 or mar1")
+	rootCmd.PersistentFlags().Bool("done", false, "Include done tasks, struck through")
+	rootCmd.PersistentFlags().String("week-link", "", "Go template turning each week number into a link, such as [[{{.ISOYear}}-W{{.Week}}]]")
 
-	// handleVersionFlag checks if the version flag is set and prints the version if it is
-	handleVersionFlag := func(cmd *cobra.Command) bool {
-		versionFlag, _ := cmd.Flags().GetBool("version")
-		if versionFlag {
-			fmt.Printf("mdcal v%v\n", getVersion())
-			return true
+	rootCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		// Handle version flag
+		if handleVersionFlag(cmd) {
+			os.Exit(0)
 		}
-		return false
-	}
-
-	// initOptionsFromFlags initializes calendar options from command-line flags
-	initOptionsFromFlags := func(cmd *cobra.Command) calendar.Options {
-		weekStart, _ := cmd.Flags().GetString("start")
-		noWeekNo, _ := cmd.Flags().GetBool("no-week-no")
-		workweek, _ := cmd.Flags().GetBool("workweek")
-		noComment, _ := cmd.Flags().GetBool("no-comment")
-		shortDayNames, _ := cmd.Flags().GetBool("short")
-		justify, _ := cmd.Flags().GetString("justify")
-
-		options := calendar.NewOptions()
-		options.FirstDayOfWeek = utils.ParseWeekday(weekStart)
-		options.ShowCalendarWeek = !noWeekNo
-		options.ShowWeekends = !workweek
-		options.ShowComments = !noComment
-		options.UseShortDayNames = shortDayNames
-		options.Justify = justify
-
-		return options
-	}
-
-	// shouldRunInteractively determines if the program should run in interactive mode
-	shouldRunInteractively := func(cmd *cobra.Command, args []string) bool {
-		return cmd.Flags().NFlag() == 0 && len(args) == 0
-	}
-
-	// processYearArg processes the year argument if present
-	processYearArg := func(args []string, options *calendar.Options) {
-		if len(args) > 0 {
-			if year, err := strconv.Atoi(args[0]); err == nil {
-				if year < 1 || year > 9999 {
-					fmt.Println("Year must be between 1 and 9999, using current year")
-				} else {
-					options.Year = year
-				}
-			} else {
-				fmt.Println("Invalid year, using current year")
-			}
+
+		// Initialize options from flags
+		options, err := initOptionsFromFlags(cmd)
+		if err != nil {
+			return err
 		}
-	}
 
-	// processMonthArg processes the month argument if present
-	processMonthArg := func(args []string, options *calendar.Options) {
-		if len(args) > 1 {
-			if month, err := strconv.Atoi(args[1]); err == nil && month >= 1 && month <= 12 {
-				options.Month = &month
-			} else {
-				fmt.Println("Invalid month, generating calendar for the whole year")
+		if shouldRunInteractively(cmd, args) {
+			// Run in interactive mode
+			// Only generate the calendar if the user completed the interactive mode
+			if completed := interactive.RunInteractiveMode(&options); completed {
+				// Generate and print calendar
+				return writeCalendar(cmd, options)
 			}
+			return nil
+		}
+
+		// Process command-line arguments
+		if err := processCommandLineArgs(args, &options); err != nil {
+			return err
 		}
+
+		// Generate and print calendar
+		return writeCalendar(cmd, options)
 	}
+}
 
-	// processDateRangeArgs processes date range arguments if present
-	processDateRangeArgs := func(args []string, options *calendar.Options) {
-		if len(args) == 3 {
-			// If we have 3 args, it's year month endMonth (same year)
-			if endMonth, err := strconv.Atoi(args[2]); err == nil && endMonth >= 1 && endMonth <= 12 {
-				endYear := options.Year
-				options.EndYear = &endYear
-				options.EndMonth = &endMonth
-			} else {
-				fmt.Println("Invalid end month, ignoring range")
-			}
-		} else if len(args) == 4 {
-			// If we have 4 args, it's year month endYear endMonth
-			endYear, errYear := strconv.Atoi(args[2])
-			endMonth, errMonth := strconv.Atoi(args[3])
-
-			if errYear == nil && errMonth == nil && endMonth >= 1 && endMonth <= 12 {
-				if endYear < 1 || endYear > 9999 {
-					fmt.Println("End year must be between 1 and 9999, ignoring range")
-					options.EndYear = nil
-					options.EndMonth = nil
-				} else {
-					options.EndYear = &endYear
-					options.EndMonth = &endMonth
-				}
-			} else {
-				if errYear != nil {
-					fmt.Println("Invalid end year, ignoring range")
-				} else if endYear < 1 || endYear > 9999 {
-					fmt.Println("End year must be between 1 and 9999, ignoring range")
-				}
-				if errMonth != nil || endMonth < 1 || endMonth > 12 {
-					fmt.Println("Invalid end month, ignoring range")
-				}
-				options.EndYear = nil
-				options.EndMonth = nil
-			}
+// handleVersionFlag checks if the version flag is set and prints the version if it is
+func handleVersionFlag(cmd *cobra.Command) bool {
+	versionFlag, _ := cmd.Flags().GetBool("version")
+	if versionFlag {
+		fmt.Printf("mdcal v%v\n", getVersion())
+		return true
+	}
+	return false
+}
+
+// initOptionsFromFlags initializes calendar options from command-line flags
+func initOptionsFromFlags(cmd *cobra.Command) (calendar.Options, error) {
+	weekStart, _ := cmd.Flags().GetString("start")
+	noWeekNo, _ := cmd.Flags().GetBool("no-week-no")
+	workweek, _ := cmd.Flags().GetBool("workweek")
+	noComment, _ := cmd.Flags().GetBool("no-comment")
+	shortDayNames, _ := cmd.Flags().GetBool("short")
+	justify, _ := cmd.Flags().GetString("justify")
+	format, _ := cmd.Flags().GetString("format")
+	layout, _ := cmd.Flags().GetString("layout")
+	alignWeekdays, _ := cmd.Flags().GetBool("align-weekdays")
+	columns, _ := cmd.Flags().GetInt("columns")
+	transpose, _ := cmd.Flags().GetBool("transpose")
+	checkboxes, _ := cmd.Flags().GetBool("checkboxes")
+	hours, _ := cmd.Flags().GetString("hours")
+	slot, _ := cmd.Flags().GetDuration("slot")
+	habits, _ := cmd.Flags().GetStringArray("habit")
+	holidayValues, _ := cmd.Flags().GetStringArray("holiday")
+	output, _ := cmd.Flags().GetString("output")
+	journalDir, _ := cmd.Flags().GetString("journal")
+	journalPattern, _ := cmd.Flags().GetString("journal-pattern")
+	journalMissing, _ := cmd.Flags().GetString("journal-missing")
+	dayLink, _ := cmd.Flags().GetString("day-link")
+	weekLink, _ := cmd.Flags().GetString("week-link")
+	sprintStart, _ := cmd.Flags().GetString("sprint-start")
+
+	firstDayOfWeek, err := calendar.ParseWeekday(weekStart)
+	if err != nil {
+		return calendar.Options{}, err
+	}
+	holidays, err := parseHolidays(holidayValues)
+	if err != nil {
+		return calendar.Options{}, err
+	}
+	dayStart, dayEnd, err := parseHours(hours)
+	if err != nil {
+		return calendar.Options{}, err
+	}
+
+	options := calendar.NewOptions()
+	options.FirstDayOfWeek = firstDayOfWeek
+	options.ShowCalendarWeek = !noWeekNo
+	options.ShowWeekends = !workweek
+	options.ShowComments = !noComment
+	options.UseShortDayNames = shortDayNames
+	options.Justify = justify
+	options.Format = format
+	options.Layout = layout
+	options.AlignWeekdays = alignWeekdays
+	options.Columns = columns
+	options.Transpose = transpose
+	options.Checkboxes = checkboxes
+	options.DayStart = dayStart
+	options.DayEnd = dayEnd
+	options.Slot = slot
+	options.Habits = habits
+	if len(holidays) > 0 {
+		options.AddAnnotator(holidayPriority, calendar.Holidays(holidays))
+	}
+	if dayLink != "" && journalDir != "" {
+		return calendar.Options{}, fmt.Errorf("--day-link and --journal both link the days and cannot be combined")
+	}
+	if dayLink != "" {
+		if options.DayLabel, err = parseDayLink(dayLink); err != nil {
+			return calendar.Options{}, err
+		}
+	}
+	if weekLink != "" {
+		if options.WeekLabel, err = parseWeekLink(weekLink); err != nil {
+			return calendar.Options{}, err
+		}
+	}
+	if sprintStart != "" {
+		sprints, err := initSprintsFromFlags(cmd)
+		if err != nil {
+			return calendar.Options{}, err
 		}
+		// Sprint names follow the week number, or the --week-link label, in the CW column
+		options.WeekLabel = sprints.WeekLabel(options.WeekLabel, options.FirstDayOfWeek)
+		options.AddAnnotator(-10, sprints.Annotator())
 	}
+	if journalDir != "" {
+		// Links to the notes are relative to the file the calendar is written to
+		journal := sources.Journal{Dir: journalDir, Pattern: journalPattern, Base: filepath.Dir(output), Missing: journalMissing}
+		if options.DayLabel, err = journal.DayLabel(); err != nil {
+			return calendar.Options{}, err
+		}
+	}
+	if err := addEventSource(cmd, &options); err != nil {
+		return calendar.Options{}, err
+	}
+	if err := addTaskSources(cmd, &options); err != nil {
+		return calendar.Options{}, err
+	}
+	if err := addOrgSource(cmd, &options); err != nil {
+		return calendar.Options{}, err
+	}
+	if err := addContactSource(cmd, &options); err != nil {
+		return calendar.Options{}, err
+	}
+
+	return options, nil
+}
 
-	// processCommandLineArgs processes all command-line arguments
-	processCommandLineArgs := func(args []string, options *calendar.Options) {
-		processYearArg(args, options)
-		processMonthArg(args, options)
-		processDateRangeArgs(args, options)
+// initSprintsFromFlags initializes the sprint cadence from the sprint flags
+func initSprintsFromFlags(cmd *cobra.Command) (calendar.Sprints, error) {
+	startValue, _ := cmd.Flags().GetString("sprint-start")
+	lengthValue, _ := cmd.Flags().GetString("sprint-length")
+	nameValue, _ := cmd.Flags().GetString("sprint-name")
+
+	start, err := time.Parse(time.DateOnly, strings.TrimSpace(startValue))
+	if err != nil {
+		return calendar.Sprints{}, fmt.Errorf("invalid sprint start %q: expected YYYY-MM-DD", startValue)
+	}
+	length, _, err := parseLength("sprint length", lengthValue)
+	if err != nil {
+		return calendar.Sprints{}, err
+	}
+	name, err := parseSprintName(nameValue)
+	if err != nil {
+		return calendar.Sprints{}, err
 	}
 
-	rootCmd.Run = func(cmd *cobra.Command, args []string) {
-		// Handle version flag
-		if handleVersionFlag(cmd) {
-			os.Exit(0)
+	return calendar.Sprints{Start: start, Length: length, Name: name}, nil
+}
+
+// writeOutput streams what write produces to the file named by the output flag, or to standard output.
+// The output is written to a temporary file next to the target, which replaces the target only when writing
+// succeeds, so that a failed generation neither leaves a partial calendar nor removes an existing one.
+func writeOutput(cmd *cobra.Command, write func(w io.Writer) error) error {
+	output, _ := cmd.Flags().GetString("output")
+	if output == "" {
+		out := bufio.NewWriter(os.Stdout)
+		if err := write(out); err != nil {
+			return err
 		}
+		return out.Flush()
+	}
 
-		// Initialize options from flags
-		options := initOptionsFromFlags(cmd)
+	// Keep the permissions of an existing calendar; temporary files are only readable by their owner
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(output); err == nil {
+		mode = info.Mode().Perm()
+	}
 
-		if shouldRunInteractively(cmd, args) {
-			// Run in interactive mode
-			// Only generate the calendar if the user completed the interactive mode
-			if completed := interactive.RunInteractiveMode(&options); completed {
-				// Generate and print calendar
-				fmt.Print(calendar.PrintCalendar(options))
-			}
-		} else {
-			// Process command-line arguments
-			processCommandLineArgs(args, &options)
+	file, err := os.CreateTemp(filepath.Dir(output), "."+filepath.Base(output)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	out := bufio.NewWriter(file)
+	if err = write(out); err == nil {
+		err = out.Flush()
+	}
+	if err == nil {
+		err = file.Chmod(mode)
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), output)
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+	}
+	return err
+}
 
-			// Generate and print calendar
-			fmt.Print(calendar.PrintCalendar(options))
+// writeCalendar streams the calendar to the output as it is generated
+func writeCalendar(cmd *cobra.Command, options calendar.Options) error {
+	return writeOutput(cmd, func(w io.Writer) error {
+		return calendar.WriteCalendar(w, options)
+	})
+}
+
+// shouldRunInteractively determines if the program should run in interactive mode
+func shouldRunInteractively(cmd *cobra.Command, args []string) bool {
+	return cmd.Flags().NFlag() == 0 && len(args) == 0
+}
+
+// parseNumberArg parses a positional argument that must be a number. Whether the number is a valid year or month
+// is left to Options.Validate, so that it is reported with the other invalid options.
+func parseNumberArg(name string, value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: expected a number", name, value)
+	}
+	return n, nil
+}
+
+// processYearArg processes the year argument if present
+func processYearArg(args []string, options *calendar.Options) error {
+	if len(args) > 0 {
+		year, err := parseNumberArg("year", args[0])
+		if err != nil {
+			return err
+		}
+		options.Year = year
+	}
+	return nil
+}
+
+// processMonthArg processes the month argument if present
+func processMonthArg(args []string, options *calendar.Options) error {
+	if len(args) > 1 {
+		month, err := parseNumberArg("month", args[1])
+		if err != nil {
+			return err
 		}
+		options.Month = &month
+	}
+	return nil
+}
+
+// processDateRangeArgs processes date range arguments if present
+func processDateRangeArgs(args []string, options *calendar.Options) error {
+	switch len(args) {
+	case 3:
+		// If we have 3 args, it's year month endMonth (same year)
+		endMonth, err := parseNumberArg("end month", args[2])
+		if err != nil {
+			return err
+		}
+		endYear := options.Year
+		options.EndYear = &endYear
+		options.EndMonth = &endMonth
+	case 4:
+		// If we have 4 args, it's year month endYear endMonth
+		endYear, err := parseNumberArg("end year", args[2])
+		if err != nil {
+			return err
+		}
+		endMonth, err := parseNumberArg("end month", args[3])
+		if err != nil {
+			return err
+		}
+		options.EndYear = &endYear
+		options.EndMonth = &endMonth
+	}
+	return nil
+}
+
+// processCommandLineArgs processes all command-line arguments. Arguments that are not numbers are reported as
+// errors, and out of range values are reported by Options.Validate when the calendar is written.
+func processCommandLineArgs(args []string, options *calendar.Options) error {
+	if err := processYearArg(args, options); err != nil {
+		return err
+	}
+	if err := processMonthArg(args, options); err != nil {
+		return err
 	}
+	return processDateRangeArgs(args, options)
 }
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"errors"
+	"github.com/andre-a-alves/mdcal/cmd/calendar"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestProcessCommandLineArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		argErr  bool  // the arguments themselves are rejected
+		wantErr error // the options are rejected by Validate
+	}{
+		{name: "Month", args: []string{"2025", "3"}},
+		{name: "Range", args: []string{"2025", "11", "2026", "2"}},
+		{name: "Year not a number", args: []string{"abc"}, argErr: true},
+		{name: "End month not a number", args: []string{"2025", "3", "x"}, argErr: true},
+		{name: "Month out of range", args: []string{"2025", "13"}, wantErr: calendar.ErrInvalidMonth},
+		{name: "Year out of range", args: []string{"0"}, wantErr: calendar.ErrInvalidYear},
+		{name: "End year out of range", args: []string{"2025", "3", "10000", "1"}, wantErr: calendar.ErrInvalidYear},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := calendar.NewOptions()
+			err := processCommandLineArgs(tt.args, &options)
+			if (err != nil) != tt.argErr {
+				t.Fatalf("processCommandLineArgs() error = %v, argErr %v", err, tt.argErr)
+			}
+			if err != nil {
+				return
+			}
+			if err := options.Validate(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWriteOutput(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "page.md")
+	if err := os.WriteFile(output, []byte("existing\n"), 0o640); err != nil {
+		t.Fatal(err)
+	}
+	cmd := &cobra.Command{}
+	cmd.Flags().String("output", output, "")
+
+	failed := errors.New("invalid options")
+	err := writeOutput(cmd, func(w io.Writer) error {
+		_, _ = io.WriteString(w, "partial")
+		return failed
+	})
+	if !errors.Is(err, failed) {
+		t.Fatalf("writeOutput() error = %v, want %v", err, failed)
+	}
+	if content, _ := os.ReadFile(output); string(content) != "existing\n" {
+		t.Errorf("writeOutput() left %q after a failure, want the existing file", content)
+	}
+
+	if err := writeOutput(cmd, func(w io.Writer) error {
+		_, err := io.WriteString(w, "calendar\n")
+		return err
+	}); err != nil {
+		t.Fatalf("writeOutput() unexpected error: %v", err)
+	}
+	if content, _ := os.ReadFile(output); string(content) != "calendar\n" {
+		t.Errorf("writeOutput() wrote %q, want the calendar", content)
+	}
+	info, err := os.Stat(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o640 {
+		t.Errorf("writeOutput() changed the permissions of the existing file to %v", info.Mode().Perm())
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(output))
+	if len(entries) != 1 {
+		t.Errorf("writeOutput() left %d files behind, want only the calendar", len(entries))
+	}
+}
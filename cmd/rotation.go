@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/andre-a-alves/mdcal/cmd/calendar"
+	"github.com/andre-a-alves/mdcal/cmd/utils"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var rotationCmd = &cobra.Command{
+	Use:   "rotation [year] [month] [endMonth|endYear endMonth]",
+	Short: "Generate a calendar of who is on call or on shift each day",
+	Long: `rotation hands a duty, such as being on call, from one person to the next and names the person on duty on
+every day of the calendar, or in the CW column with --by-week, followed by a summary of the days each person is on
+duty. Turns last --length, handing off on the --handoff weekday for lengths in weeks, or follow a --pattern of turn
+lengths such as 2-2-3. Holidays and --skip days are left uncovered, and --override swaps people on given days.
+Turns are counted from --since, or from Monday January 1, 1900 by default, so calendars of any range agree as long
+as --since stays the same between runs.
+Examples:
+  mdcal rotation --people alice,bob,carol --handoff mon --length 1w 2025 1 3   - Weekly on-call for a quarter
+  mdcal rotation --people alice,bob --pattern 2-2-3 --shifts day,night 2025 3  - Day and night shifts in 2-2-3
+  mdcal rotation --people ann,ben,cat --length 1d --skip-weekends 2025 3       - Daily turns on weekdays`,
+	Args: cobra.MaximumNArgs(4),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		options, err := initOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		if err := processCommandLineArgs(args, &options); err != nil {
+			return err
+		}
+
+		byWeek, _ := cmd.Flags().GetBool("by-week")
+		noSummary, _ := cmd.Flags().GetBool("no-summary")
+		if byWeek && !options.ShowCalendarWeek {
+			return errors.New("--by-week names the people in the CW column and cannot be combined with --no-week-no")
+		}
+
+		rotation, err := initRotationFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		start, end := options.DateRange()
+		duties := rotation.Between(start, end)
+
+		options.AddAnnotator(0, duties.Annotator(byWeek))
+		if byWeek {
+			options.WeekLabel = duties.WeekLabel(options.WeekLabel, rotation.Shifts, options.FirstDayOfWeek)
+		}
+
+		return writeOutput(cmd, func(w io.Writer) error {
+			out := &tailWriter{w: w}
+			if err := calendar.WriteCalendar(out, options); err != nil {
+				return err
+			}
+			if noSummary {
+				return nil
+			}
+			summary := duties.Summary(rotation.People, rotation.Shifts, options.Justify)
+			if !out.endsWith("\n\n") {
+				// Some layouts already end with a blank line, such as calendars of several months
+				summary = "\n" + summary
+			}
+			_, err := io.WriteString(w, summary)
+			return err
+		})
+	},
+}
+
+func init() {
+	rotationCmd.Flags().StringSlice("people", nil, "People taking turns, in order, such as alice,bob,carol")
+	rotationCmd.Flags().String("length", "1w", "Length of every turn, in weeks or days such as 1w or 3d")
+	rotationCmd.Flags().String("handoff", "mon", "Weekday turns hand off on, for lengths in weeks")
+	rotationCmd.Flags().String("pattern", "", "Lengths of successive turns in days, such as 2-2-3, instead of --length")
+	rotationCmd.Flags().StringSlice("shifts", nil, "Names of the shifts of every day, such as day,night")
+	rotationCmd.Flags().String("since", "", "First day of the first turn, as YYYY-MM-DD (default: Monday January 1, 1900)")
+	rotationCmd.Flags().StringArray("skip", nil, "Leave a day uncovered, as YYYY-MM-DD or YYYY-MM-DD..YYYY-MM-DD (repeatable)")
+	rotationCmd.Flags().Bool("skip-weekends", false, "Leave Saturdays and Sundays uncovered")
+	rotationCmd.Flags().StringArray("override", nil, "Swap in a person, as DAYS=person or DAYS/shift=person (repeatable)")
+	rotationCmd.Flags().Bool("by-week", false, "Name the people of each week in the CW column, and only swaps in the days")
+	rotationCmd.Flags().Bool("no-summary", false, "Leave out the summary of the days each person is on duty")
+
+	rootCmd.AddCommand(rotationCmd)
+}
+
+// rotationEpoch is the first day of the first turn unless --since is set. It is fixed, so that calendars of any range
+// agree on who is on duty.
+var rotationEpoch = time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// initRotationFromFlags initializes the rotation from the rotation flags, starting on rotationEpoch unless --since
+// is set
+func initRotationFromFlags(cmd *cobra.Command) (calendar.Rotation, error) {
+	people, _ := cmd.Flags().GetStringSlice("people")
+	lengthValue, _ := cmd.Flags().GetString("length")
+	handoffValue, _ := cmd.Flags().GetString("handoff")
+	patternValue, _ := cmd.Flags().GetString("pattern")
+	shifts, _ := cmd.Flags().GetStringSlice("shifts")
+	since, _ := cmd.Flags().GetString("since")
+	skipValues, _ := cmd.Flags().GetStringArray("skip")
+	skipWeekends, _ := cmd.Flags().GetBool("skip-weekends")
+	overrideValues, _ := cmd.Flags().GetStringArray("override")
+	holidayValues, _ := cmd.Flags().GetStringArray("holiday")
+
+	people = trimValues(people)
+	shifts = trimValues(shifts)
+	if len(people) == 0 {
+		return calendar.Rotation{}, errors.New("no people given: use --people, such as --people alice,bob,carol")
+	}
+
+	length, weeks, err := parseLength("length", lengthValue)
+	if err != nil {
+		return calendar.Rotation{}, err
+	}
+	pattern := []int{length}
+	if patternValue != "" {
+		if pattern, err = parsePattern(patternValue); err != nil {
+			return calendar.Rotation{}, err
+		}
+		weeks = false
+	}
+	handoff, ok := utils.LookupWeekday(strings.TrimSpace(handoffValue))
+	if !ok {
+		return calendar.Rotation{}, fmt.Errorf("invalid handoff %q: expected a weekday such as mon", handoffValue)
+	}
+
+	start := rotationEpoch
+	if since != "" {
+		if start, err = time.Parse(time.DateOnly, strings.TrimSpace(since)); err != nil {
+			return calendar.Rotation{}, fmt.Errorf("invalid since %q: expected YYYY-MM-DD", since)
+		}
+	}
+	if weeks {
+		// Weekly turns start on the handoff day on or before the first day
+		start = start.AddDate(0, 0, -((int(start.Weekday())-int(handoff))%7+7)%7)
+	}
+
+	holidays, err := parseHolidays(holidayValues)
+	if err != nil {
+		return calendar.Rotation{}, err
+	}
+	skips, err := parseSkips(skipValues)
+	if err != nil {
+		return calendar.Rotation{}, err
+	}
+	overrides, err := parseOverrides(overrideValues, shifts)
+	if err != nil {
+		return calendar.Rotation{}, err
+	}
+
+	return calendar.Rotation{
+		People:  people,
+		Shifts:  shifts,
+		Start:   start,
+		Pattern: pattern,
+		// Turns of whole weeks and patterns follow the calendar, while daily turns pause on skipped days
+		Continuous:   weeks || patternValue != "",
+		SkipWeekends: skipWeekends,
+		Skip: func(date time.Time) bool {
+			day := date.Format(time.DateOnly)
+			_, holiday := holidays[day]
+			return holiday || skips[day]
+		},
+		Overrides: overrides,
+	}, nil
+}
+
+// trimValues trims the values of a list flag and drops the empty ones
+func trimValues(values []string) []string {
+	var trimmed []string
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			trimmed = append(trimmed, value)
+		}
+	}
+	return trimmed
+}
+
+// tailWriter passes writes on to w and remembers the last bytes written
+type tailWriter struct {
+	w    io.Writer
+	tail []byte
+}
+
+func (t *tailWriter) Write(p []byte) (int, error) {
+	t.tail = append(t.tail, p...)
+	if len(t.tail) > 2 {
+		t.tail = t.tail[len(t.tail)-2:]
+	}
+	return t.w.Write(p)
+}
+
+// endsWith reports whether the output written so far ends with suffix, of at most two bytes
+func (t *tailWriter) endsWith(suffix string) bool {
+	return strings.HasSuffix(string(t.tail), suffix)
+}
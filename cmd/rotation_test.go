@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"github.com/andre-a-alves/mdcal/cmd/calendar"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/cobra"
+)
+
+func TestRotationAcrossRanges(t *testing.T) {
+	tests := []struct {
+		name         string
+		length       string
+		pattern      string
+		skipWeekends bool
+		byWeek       bool // compare the week labels, which only name the people of the days shown
+	}{
+		{name: "Weekly turns", length: "1w", byWeek: true},
+		{name: "Daily turns on weekdays", length: "1d", skipWeekends: true},
+		{name: "Pattern", length: "1w", pattern: "2-2-3"},
+	}
+
+	// duties returns the duties of the calendar selected by args, and the week label of January 1, 2026
+	duties := func(t *testing.T, cmd *cobra.Command, args []string) (calendar.Duties, string) {
+		t.Helper()
+		options := calendar.NewOptions()
+		if err := processCommandLineArgs(args, &options); err != nil {
+			t.Fatal(err)
+		}
+		rotation, err := initRotationFromFlags(cmd)
+		if err != nil {
+			t.Fatal(err)
+		}
+		duties := rotation.Between(options.DateRange())
+		return duties, duties.WeekLabel(nil, nil, time.Monday)(time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC), "1")
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().StringSlice("people", []string{"alice", "bob", "carol"}, "")
+			cmd.Flags().String("length", tt.length, "")
+			cmd.Flags().String("handoff", "mon", "")
+			cmd.Flags().String("pattern", tt.pattern, "")
+			cmd.Flags().Bool("skip-weekends", tt.skipWeekends, "")
+
+			newYear, newYearLabel := duties(t, cmd, []string{"2025", "12", "2026", "1"})
+			january, januaryLabel := duties(t, cmd, []string{"2026", "1"})
+
+			if len(january) == 0 {
+				t.Fatal("Between() returned no duties for January")
+			}
+			for date, got := range january {
+				if diff := cmp.Diff(newYear[date], got); diff != "" {
+					t.Errorf("duties of %s differ between ranges (-December to January +January):\n%s", date, diff)
+				}
+			}
+			if tt.byWeek && newYearLabel != januaryLabel {
+				t.Errorf("week of January 1 is labelled %q in December to January, but %q in January", newYearLabel, januaryLabel)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package schedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// cronMacros maps the @ shorthands of cron to their expressions
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronNames maps the month and weekday names of cron to their values
+var cronNames = map[string]int{
+	"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6, "JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+}
+
+// Cron is a five field cron expression: minute, hour, day of month, month and day of week
+type Cron struct {
+	minute, hour, dayOfMonth, month, dayOfWeek field
+}
+
+// lookupCronName returns the value of a month or weekday name of cron, such as JAN or MON
+func lookupCronName(name string) (int, bool) {
+	n, ok := cronNames[strings.ToUpper(name)]
+	return n, ok
+}
+
+// ParseCron parses a five field cron expression such as "0 3 * * 1-5", or a shorthand such as @daily.
+// Fields accept lists, ranges, steps and month and weekday names, and 7 is Sunday like 0.
+func ParseCron(expr string) (Cron, error) {
+	expr = strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return Cron{}, fmt.Errorf("invalid cron expression %q: expected 5 fields", expr)
+	}
+
+	var c Cron
+	var err error
+	names := []string{"minute", "hour", "day of month", "month", "day of week"}
+	targets := []*field{&c.minute, &c.hour, &c.dayOfMonth, &c.month, &c.dayOfWeek}
+	limits := [][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+	for i, value := range fields {
+		if *targets[i], err = parseField(value, "-", limits[i][0], limits[i][1], lookupCronName); err != nil {
+			return Cron{}, fmt.Errorf("invalid cron expression %q: %s: %w", expr, names[i], err)
+		}
+	}
+	if c.dayOfWeek.values[7] {
+		c.dayOfWeek.values[0] = true
+	}
+	return c, nil
+}
+
+// Matches reports whether the expression fires in the minute of t. As in cron, a day matches either the day of
+// month or the day of week when neither is written starting with *.
+func (c Cron) Matches(t time.Time) bool {
+	if !c.minute.matches(t.Minute()) || !c.hour.matches(t.Hour()) || !c.month.matches(int(t.Month())) {
+		return false
+	}
+
+	dayOfMonth, dayOfWeek := c.dayOfMonth.matches(t.Day()), c.dayOfWeek.matches(int(t.Weekday()))
+	if !c.dayOfMonth.star && !c.dayOfWeek.star {
+		return dayOfMonth || dayOfWeek
+	}
+	return dayOfMonth && dayOfWeek
+}
@@ -0,0 +1,134 @@
+package schedule
+
+import (
+	"fmt"
+	"github.com/andre-a-alves/mdcal/cmd/utils"
+	"strings"
+	"time"
+)
+
+// onCalendarShorthands maps the shorthands of systemd calendar events to their normalized form
+var onCalendarShorthands = map[string]string{
+	"minutely":     "*-*-* *:*:00",
+	"hourly":       "*-*-* *:00:00",
+	"daily":        "*-*-* 00:00:00",
+	"weekly":       "Mon *-*-* 00:00:00",
+	"monthly":      "*-*-01 00:00:00",
+	"yearly":       "*-01-01 00:00:00",
+	"annually":     "*-01-01 00:00:00",
+	"quarterly":    "*-01,04,07,10-01 00:00:00",
+	"semiannually": "*-01,07-01 00:00:00",
+}
+
+// OnCalendar is a systemd calendar event, such as "Mon..Fri *-*-* 03:00", matched to the minute
+type OnCalendar struct {
+	weekday, year, month, day, hour, minute field
+	dayFromEnd                              bool // whether day counts back from the end of the month, as in *-02~03
+}
+
+// lookupWeekday returns the weekday of a name such as Mon or Monday
+func lookupWeekday(name string) (int, bool) {
+	weekday, ok := utils.LookupWeekday(name)
+	return int(weekday), ok
+}
+
+// noNames accepts no names, for components that only take numbers
+func noNames(string) (int, bool) {
+	return 0, false
+}
+
+// isWeekdaySpec reports whether token lists weekdays, such as Mon,Wed or Mon..Fri
+func isWeekdaySpec(token string) bool {
+	for _, part := range strings.Split(token, ",") {
+		for _, name := range strings.Split(part, "..") {
+			if _, ok := utils.LookupWeekday(name); !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ParseOnCalendar parses an OnCalendar= value of a systemd timer, such as "Mon..Fri *-*-* 03:00 Europe/Berlin"
+// or a shorthand such as daily, returning the time zone it names or nil when it names none. Seconds are accepted
+// but the event is matched to the minute.
+func ParseOnCalendar(value string) (OnCalendar, *time.Location, error) {
+	value = strings.TrimSpace(value)
+	if normalized, ok := onCalendarShorthands[strings.ToLower(value)]; ok {
+		value = normalized
+	}
+
+	weekdays, date, clock := "*", "*-*-*", "00:00:00"
+	var location *time.Location
+	for i, token := range strings.Fields(value) {
+		switch {
+		case i == 0 && isWeekdaySpec(token):
+			weekdays = token
+		case strings.Contains(token, ":"):
+			clock = token
+		case strings.Contains(token, "-") && (token[0] == '*' || (token[0] >= '0' && token[0] <= '9')):
+			date = token
+		default:
+			loc, err := time.LoadLocation(token)
+			if err != nil {
+				return OnCalendar{}, nil, fmt.Errorf("invalid calendar event %q: unknown component %q", value, token)
+			}
+			location = loc
+		}
+	}
+
+	var c OnCalendar
+	var err error
+	if c.weekday, err = parseField(weekdays, "..", 0, 6, lookupWeekday); err != nil {
+		return OnCalendar{}, nil, fmt.Errorf("invalid calendar event %q: weekday: %w", value, err)
+	}
+
+	datePart, fromEnd, hasTilde := strings.Cut(date, "~")
+	dateParts := strings.Split(datePart, "-")
+	if hasTilde {
+		dateParts = append(dateParts, fromEnd)
+		c.dayFromEnd = true
+	}
+	if len(dateParts) == 2 {
+		dateParts = append([]string{"*"}, dateParts...)
+	}
+	clockParts := strings.Split(clock, ":")
+	if len(dateParts) != 3 || len(clockParts) < 2 || len(clockParts) > 3 {
+		return OnCalendar{}, nil, fmt.Errorf("invalid calendar event %q: expected [weekdays] [year-]month-day hour:minute[:second]", value)
+	}
+
+	components := []struct {
+		name     string
+		value    string
+		target   *field
+		min, max int
+	}{
+		{"year", dateParts[0], &c.year, 1970, 2199},
+		{"month", dateParts[1], &c.month, 1, 12},
+		{"day", dateParts[2], &c.day, 1, 31},
+		{"hour", clockParts[0], &c.hour, 0, 23},
+		{"minute", clockParts[1], &c.minute, 0, 59},
+	}
+	for _, component := range components {
+		if *component.target, err = parseField(component.value, "..", component.min, component.max, noNames); err != nil {
+			return OnCalendar{}, nil, fmt.Errorf("invalid calendar event %q: %s: %w", value, component.name, err)
+		}
+	}
+	if len(clockParts) == 3 {
+		if _, err := parseField(clockParts[2], "..", 0, 59, noNames); err != nil {
+			return OnCalendar{}, nil, fmt.Errorf("invalid calendar event %q: second: %w", value, err)
+		}
+	}
+
+	return c, location, nil
+}
+
+// Matches reports whether the event fires in the minute of t
+func (c OnCalendar) Matches(t time.Time) bool {
+	day := t.Day()
+	if c.dayFromEnd {
+		day = time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day() - day + 1
+	}
+	return c.weekday.matches(int(t.Weekday())) && c.year.matches(t.Year()) && c.month.matches(int(t.Month())) &&
+		c.day.matches(day) && c.hour.matches(t.Hour()) && c.minute.matches(t.Minute())
+}
@@ -0,0 +1,102 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule reports whether a job fires in the minute of t, reading t in its own time zone
+type Schedule interface {
+	Matches(t time.Time) bool
+}
+
+// Job is a named schedule together with the time zone it is read in
+type Job struct {
+	Name     string
+	Schedule Schedule
+	Location *time.Location // nil to read the schedule in the time zone of the times it is checked against
+}
+
+// Times returns the minutes between from, inclusive, and to, exclusive, in which the job fires, in the time zone of from
+func (j Job) Times(from time.Time, to time.Time) []time.Time {
+	var times []time.Time
+	for t := from.Truncate(time.Minute); t.Before(to); t = t.Add(time.Minute) {
+		local := t
+		if j.Location != nil {
+			local = t.In(j.Location)
+		}
+		if j.Schedule.Matches(local) {
+			times = append(times, t)
+		}
+	}
+	return times
+}
+
+// field is the set of values a component of a schedule accepts, such as the minutes of a cron expression
+type field struct {
+	values map[int]bool
+	star   bool // whether the component starts with *, such as * or */15
+}
+
+// matches reports whether the field accepts v
+func (f field) matches(v int) bool {
+	return f.values[v]
+}
+
+// parseValue parses a number, or a name known to lookup, between min and max
+func parseValue(value string, min int, max int, lookup func(name string) (int, bool)) (int, error) {
+	n, ok := lookup(value)
+	if !ok {
+		var err error
+		if n, err = strconv.Atoi(value); err != nil {
+			return 0, fmt.Errorf("invalid value %q", value)
+		}
+	}
+	if n < min || n > max {
+		return 0, fmt.Errorf("value %d out of range %d-%d", n, min, max)
+	}
+	return n, nil
+}
+
+// parseField parses a comma separated list of values, ranges joined by rangeSeparator, and steps such as */15,
+// 5/15 or 1-30/2, between min and max. lookup turns names, such as those of months or weekdays, into values.
+func parseField(value string, rangeSeparator string, min int, max int, lookup func(name string) (int, bool)) (field, error) {
+	f := field{values: make(map[int]bool), star: strings.HasPrefix(value, "*")}
+	for _, part := range strings.Split(value, ",") {
+		span, stepValue, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepValue); err != nil || step < 1 {
+				return field{}, fmt.Errorf("invalid step %q", stepValue)
+			}
+		}
+
+		low, high := min, max
+		if span != "*" {
+			lowValue, highValue, isRange := strings.Cut(span, rangeSeparator)
+			var err error
+			if low, err = parseValue(lowValue, min, max, lookup); err != nil {
+				return field{}, err
+			}
+			high = low
+			if isRange {
+				if high, err = parseValue(highValue, min, max, lookup); err != nil {
+					return field{}, err
+				}
+			} else if hasStep {
+				// A start with a step, such as 5/15, runs to the end of the range
+				high = max
+			}
+		}
+		if low > high {
+			return field{}, fmt.Errorf("invalid range %q", span)
+		}
+		for v := low; v <= high; v += step {
+			f.values[v] = true
+		}
+	}
+	return f, nil
+}
@@ -0,0 +1,165 @@
+package schedule
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// at returns the minute of March 2025 in UTC
+func at(day int, hour int, minute int) time.Time {
+	return time.Date(2025, time.March, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestCronMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		time     time.Time
+		expected bool
+	}{
+		{name: "Weekday at 03:00", expr: "0 3 * * 1-5", time: at(14, 3, 0), expected: true},
+		{name: "Weekend at 03:00", expr: "0 3 * * 1-5", time: at(15, 3, 0)},
+		{name: "Other minute", expr: "0 3 * * 1-5", time: at(14, 3, 1)},
+		{name: "Step", expr: "*/15 * * * *", time: at(14, 10, 45), expected: true},
+		{name: "Range with step", expr: "0 8-18/2 * * *", time: at(14, 9, 0)},
+		{name: "Names", expr: "30 2 * mar sun", time: at(16, 2, 30), expected: true},
+		{name: "Sunday as 7", expr: "0 0 * * 7", time: at(16, 0, 0), expected: true},
+		{name: "Day of month or day of week", expr: "0 0 1 * 5", time: at(14, 0, 0), expected: true},
+		{name: "Day of month and starred day of week", expr: "0 0 1 * */2", time: at(14, 0, 0)},
+		{name: "Macro", expr: "@monthly", time: at(1, 0, 0), expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron(%q) unexpected error: %v", tt.expr, err)
+			}
+			if actual := cron.Matches(tt.time); actual != tt.expected {
+				t.Errorf("Matches(%s) = %v, want %v", tt.time.Format(time.DateTime), actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseCronErrors(t *testing.T) {
+	tests := []struct {
+		expr        string
+		expectedErr string
+	}{
+		{expr: "0 3 * *", expectedErr: "expected 5 fields"},
+		{expr: "60 3 * * *", expectedErr: "minute: value 60 out of range 0-59"},
+		{expr: "0 3 * * 5-1", expectedErr: "day of week: invalid range"},
+		{expr: "*/0 * * * *", expectedErr: "minute: invalid step"},
+		{expr: "0 3 * foo *", expectedErr: "month: invalid value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := ParseCron(tt.expr)
+			if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+				t.Errorf("ParseCron(%q) error = %v, want %q", tt.expr, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestOnCalendarMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		time     time.Time
+		expected bool
+	}{
+		{name: "Weekday range", value: "Mon..Fri *-*-* 03:00", time: at(14, 3, 0), expected: true},
+		{name: "Weekday range, weekend", value: "Mon..Fri *-*-* 03:00", time: at(15, 3, 0)},
+		{name: "Time only", value: "04:30", time: at(20, 4, 30), expected: true},
+		{name: "Repetition", value: "*:0/20", time: at(20, 11, 40), expected: true},
+		{name: "Month and day", value: "*-03-14 12:00:00", time: at(14, 12, 0), expected: true},
+		{name: "Last day of the month", value: "*-*~01 23:00", time: at(31, 23, 0), expected: true},
+		{name: "Third last day of March", value: "*-03~03", time: at(29, 0, 0), expected: true},
+		{name: "Shorthand", value: "weekly", time: at(17, 0, 0), expected: true},
+		{name: "Shorthand, other day", value: "weekly", time: at(18, 0, 0)},
+		{name: "Year", value: "2026-*-* 00:00", time: at(1, 0, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, _, err := ParseOnCalendar(tt.value)
+			if err != nil {
+				t.Fatalf("ParseOnCalendar(%q) unexpected error: %v", tt.value, err)
+			}
+			if actual := event.Matches(tt.time); actual != tt.expected {
+				t.Errorf("Matches(%s) = %v, want %v", tt.time.Format(time.DateTime), actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseOnCalendarErrors(t *testing.T) {
+	tests := []struct {
+		value       string
+		expectedErr string
+	}{
+		{value: "*-*-* 25:00", expectedErr: "hour: value 25 out of range"},
+		{value: "*-13-01", expectedErr: "month: value 13 out of range"},
+		{value: "Mon..Fri 03:00 Mars/Olympus", expectedErr: `unknown component "Mars/Olympus"`},
+		{value: "*-*-*-* 03:00", expectedErr: "expected [weekdays]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			_, _, err := ParseOnCalendar(tt.value)
+			if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+				t.Errorf("ParseOnCalendar(%q) error = %v, want %q", tt.value, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestJobTimes(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	cron, err := ParseCron("30 2 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		location *time.Location
+		day      time.Time
+		expected []time.Time
+	}{
+		{
+			name:     "Zone of the calendar",
+			day:      time.Date(2025, time.March, 14, 0, 0, 0, 0, berlin),
+			expected: []time.Time{time.Date(2025, time.March, 14, 2, 30, 0, 0, berlin)},
+		},
+		{
+			name:     "Job in another zone",
+			location: time.UTC,
+			day:      time.Date(2025, time.March, 14, 0, 0, 0, 0, berlin),
+			expected: []time.Time{time.Date(2025, time.March, 14, 3, 30, 0, 0, berlin)},
+		},
+		{
+			name: "Skipped by the change to summer time",
+			day:  time.Date(2025, time.March, 30, 0, 0, 0, 0, berlin),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := Job{Schedule: cron, Location: tt.location}
+			actual := job.Times(tt.day, tt.day.AddDate(0, 0, 1))
+			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+				t.Errorf("Times() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
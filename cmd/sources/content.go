@@ -0,0 +1,206 @@
+package sources
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/andre-a-alves/mdcal/cmd/calendar"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Post is a page of a static site placed on its publish day
+type Post struct {
+	File  string
+	Title string
+	Date  time.Time
+	Draft bool
+}
+
+var (
+	// frontMatterDatePattern matches the day at the start of a front matter date, such as 2025-03-14T10:00:00+01:00
+	frontMatterDatePattern = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})`)
+	// postFileDatePattern matches the day Jekyll posts carry in their file name, such as 2025-03-14-hello.md
+	postFileDatePattern = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})-`)
+)
+
+// frontMatterValue unquotes a YAML or TOML scalar and drops a trailing comment
+func frontMatterValue(value string) string {
+	value = strings.TrimSpace(value)
+	switch {
+	case strings.HasPrefix(value, `"`):
+		if end := strings.LastIndex(value, `"`); end > 0 {
+			if unquoted, err := strconv.Unquote(value[:end+1]); err == nil {
+				return unquoted
+			}
+			return value[1:end]
+		}
+	case strings.HasPrefix(value, "'"):
+		if end := strings.LastIndex(value, "'"); end > 0 {
+			return strings.ReplaceAll(value[1:end], "''", "'")
+		}
+	}
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+	return value
+}
+
+// parseFrontMatter reads the top-level keys of the YAML (---) or TOML (+++) front matter at the start of r.
+// Only the front matter is read, and nested keys, lists and tables are skipped.
+func parseFrontMatter(r io.Reader) (map[string]string, error) {
+	scanner := bufio.NewScanner(r)
+	if !scanner.Scan() {
+		return nil, scanner.Err()
+	}
+	delimiter := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+	separator := map[string]string{"---": ":", "+++": "="}[delimiter]
+	if separator == "" {
+		return nil, nil
+	}
+
+	fields := make(map[string]string)
+	inTable := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == delimiter {
+			return fields, nil
+		}
+		if separator == "=" && strings.HasPrefix(strings.TrimSpace(line), "[") {
+			// Keys below a TOML table header, such as [params], are not top-level
+			inTable = true
+		}
+		if inTable || line == "" || line[0] == ' ' || line[0] == '\t' || line[0] == '#' || line[0] == '-' {
+			continue
+		}
+		key, value, found := strings.Cut(line, separator)
+		if !found {
+			continue
+		}
+		fields[strings.TrimSpace(key)] = frontMatterValue(value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return nil, fmt.Errorf("front matter is not closed by %s", delimiter)
+}
+
+// ReadPost reads the publish day, title and draft state of a content file from its front matter, reporting whether
+// it has a publish day. The day is taken from publishDate, pubDate or date, or else from a Jekyll style file name.
+func ReadPost(r io.Reader, file string) (Post, bool, error) {
+	fields, err := parseFrontMatter(r)
+	if err != nil {
+		return Post{}, false, err
+	}
+
+	name := filepath.Base(file)
+	post := Post{File: file, Title: fields["title"]}
+	if post.Title == "" {
+		post.Title = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	post.Draft = fields["draft"] == "true" || fields["published"] == "false"
+
+	day := ""
+	for _, value := range []string{fields["publishDate"], fields["pubDate"], fields["date"]} {
+		if match := frontMatterDatePattern.FindStringSubmatch(value); match != nil {
+			day = match[1]
+			break
+		}
+	}
+	if match := postFileDatePattern.FindStringSubmatch(name); day == "" && match != nil {
+		day = match[1]
+	}
+	if day == "" {
+		return Post{}, false, nil
+	}
+
+	if post.Date, err = time.Parse(time.DateOnly, day); err != nil {
+		return Post{}, false, err
+	}
+	return post, true, nil
+}
+
+// isContent reports whether the file name has the extension of a static site content file
+func isContent(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".md", ".markdown", ".mdx", ".html":
+		return true
+	}
+	return false
+}
+
+// ScanContent walks the content files below dir, skipping hidden directories, and returns the posts with a publish
+// day. Only the front matter of every file is read, so large sites are scanned quickly. Files whose front matter
+// cannot be read are skipped, with a warning for each.
+func ScanContent(dir string) ([]Post, []string, error) {
+	var posts []Post
+	var warnings []string
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != dir && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !isContent(entry.Name()) {
+			return nil
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		post, ok, err := ReadPost(file, path)
+		if err != nil {
+			// A single broken post should not hide the others
+			warnings = append(warnings, fmt.Sprintf("%s: skipped, %v", path, err))
+			return nil
+		}
+		if ok {
+			posts = append(posts, post)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return posts, warnings, nil
+}
+
+// PostAnnotations places the posts on their publish days, linked to their file relative to the directory base.
+// Drafts are marked with 📝, or left out unless showDrafts is set.
+func PostAnnotations(posts []Post, base string, showDrafts bool) (map[string][]calendar.Annotation, error) {
+	annotations := make(map[string][]calendar.Annotation)
+	for _, post := range posts {
+		if post.Draft && !showDrafts {
+			continue
+		}
+
+		link, err := relativeLink(base, post.File)
+		if err != nil {
+			return nil, err
+		}
+		text := post.Title
+		if post.Draft {
+			text = "📝 " + text
+		}
+
+		date := post.Date.Format(time.DateOnly)
+		annotations[date] = append(annotations[date], calendar.Annotation{Text: text, Link: link})
+	}
+
+	return annotations, nil
+}
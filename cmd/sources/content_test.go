@@ -0,0 +1,148 @@
+package sources
+
+import (
+	"github.com/andre-a-alves/mdcal/cmd/calendar"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReadPost(t *testing.T) {
+	march := func(day int) time.Time {
+		return time.Date(2025, time.March, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name          string
+		file          string
+		content       string
+		expected      Post
+		expectedFound bool
+		expectedErr   bool
+	}{
+		{
+			name:          "YAML with publishDate",
+			file:          "launch.md",
+			content:       "---\ntitle: \"Launch: day one\"\ndate: 2025-03-01\npublishDate: 2025-03-14T10:00:00+01:00\ntags:\n  - news\n---\nBody\n",
+			expected:      Post{File: "launch.md", Title: "Launch: day one", Date: march(14)},
+			expectedFound: true,
+		},
+		{
+			name:          "TOML draft",
+			file:          "notes.md",
+			content:       "+++\ntitle = 'Release notes' # shown on the index\ndate = 2025-03-10\ndraft = true\n[params]\ndate = 2025-04-01\n+++\n",
+			expected:      Post{File: "notes.md", Title: "Release notes", Date: march(10), Draft: true},
+			expectedFound: true,
+		},
+		{
+			name:          "Astro pubDate",
+			file:          "astro.mdx",
+			content:       "---\ntitle: Astro\npubDate: '2025-03-05'\n---\n",
+			expected:      Post{File: "astro.mdx", Title: "Astro", Date: march(5)},
+			expectedFound: true,
+		},
+		{
+			name:          "Jekyll date from the file name, unpublished",
+			file:          "_posts/2025-03-07-hello-world.md",
+			content:       "---\nlayout: post\npublished: false\n---\n",
+			expected:      Post{File: "_posts/2025-03-07-hello-world.md", Title: "2025-03-07-hello-world", Date: march(7), Draft: true},
+			expectedFound: true,
+		},
+		{
+			name:    "No front matter",
+			file:    "readme.md",
+			content: "# Readme\n",
+		},
+		{
+			name:    "No date",
+			file:    "about.md",
+			content: "---\ntitle: About\n---\n",
+		},
+		{
+			name:        "Front matter not closed",
+			file:        "broken.md",
+			content:     "---\ntitle: Broken\ndate: 2025-03-01\n",
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, found, err := ReadPost(strings.NewReader(tt.content), tt.file)
+			if (err != nil) != tt.expectedErr {
+				t.Fatalf("ReadPost() error = %v, want error %v", err, tt.expectedErr)
+			}
+			if found != tt.expectedFound {
+				t.Fatalf("ReadPost() found = %v, want %v", found, tt.expectedFound)
+			}
+			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+				t.Errorf("ReadPost() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestScanContent(t *testing.T) {
+	root := t.TempDir()
+	write := func(name string, content string) {
+		t.Helper()
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("posts/launch.md", "---\ntitle: Launch\ndate: 2025-03-14\n---\n")
+	write("posts/next.md", "---\ntitle: Next\ndate: 2025-03-14\ndraft: true\n---\n")
+	write("posts/cover.png", "---\ntitle: Image\ndate: 2025-03-14\n---\n")
+	write(".git/draft.md", "---\ntitle: Hidden\ndate: 2025-03-14\n---\n")
+	write("posts/broken.md", "---\ntitle: Broken\ndate: 2025-03-14\n")
+
+	posts, warnings, err := ScanContent(root)
+	if err != nil {
+		t.Fatalf("ScanContent() unexpected error: %v", err)
+	}
+	expectedWarnings := []string{filepath.Join(root, "posts/broken.md") + ": skipped, front matter is not closed by ---"}
+	if diff := cmp.Diff(expectedWarnings, warnings); diff != "" {
+		t.Errorf("ScanContent() warnings mismatch (-want +got):\n%s", diff)
+	}
+
+	tests := []struct {
+		name       string
+		showDrafts bool
+		expected   map[string][]calendar.Annotation
+	}{
+		{
+			name: "Published posts",
+			expected: map[string][]calendar.Annotation{"2025-03-14": {
+				{Text: "Launch", Link: "posts/launch.md"},
+			}},
+		},
+		{
+			name:       "Drafts marked",
+			showDrafts: true,
+			expected: map[string][]calendar.Annotation{"2025-03-14": {
+				{Text: "Launch", Link: "posts/launch.md"},
+				{Text: "📝 Next", Link: "posts/next.md"},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := PostAnnotations(posts, root, tt.showDrafts)
+			if err != nil {
+				t.Fatalf("PostAnnotations() unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+				t.Errorf("PostAnnotations() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package sources
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/andre-a-alves/mdcal/cmd/calendar"
+	"github.com/andre-a-alves/mdcal/cmd/schedule"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// crontabVariablePattern matches the environment settings of a crontab, such as CRON_TZ=Europe/Berlin
+var crontabVariablePattern = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)\s*=\s*(.*)$`)
+
+// loadLocation loads a time zone from a line of a schedule file
+func loadLocation(line int, name string) (*time.Location, error) {
+	loc, err := time.LoadLocation(strings.Trim(strings.TrimSpace(name), `"'`))
+	if err != nil {
+		return nil, fmt.Errorf("line %d: %w", line, err)
+	}
+	return loc, nil
+}
+
+// ReadCrontab reads the jobs of a crontab, named by their command. CRON_TZ or TZ settings apply to the jobs below
+// them, and @reboot jobs, which have no schedule, are left out.
+func ReadCrontab(r io.Reader) ([]schedule.Job, error) {
+	var jobs []schedule.Job
+	var location *time.Location
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, "@reboot") {
+			continue
+		}
+		if match := crontabVariablePattern.FindStringSubmatch(text); match != nil {
+			if match[1] == "CRON_TZ" || match[1] == "TZ" {
+				var err error
+				if location, err = loadLocation(line, match[2]); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		fields := strings.Fields(text)
+		expr, command := strings.Join(fields[:min(5, len(fields))], " "), fields[min(5, len(fields)):]
+		if strings.HasPrefix(fields[0], "@") {
+			expr, command = fields[0], fields[1:]
+		}
+		cron, err := schedule.ParseCron(expr)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		jobs = append(jobs, schedule.Job{Name: strings.Join(command, " "), Schedule: cron, Location: location})
+	}
+
+	return jobs, scanner.Err()
+}
+
+// ReadTimer reads the OnCalendar= settings of a systemd timer unit as jobs named name. An empty OnCalendar=
+// clears the settings above it, as it does for systemd.
+func ReadTimer(r io.Reader, name string) ([]schedule.Job, error) {
+	var jobs []schedule.Job
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		key, value, found := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+		if !found || strings.TrimSpace(key) != "OnCalendar" {
+			continue
+		}
+		if strings.TrimSpace(value) == "" {
+			jobs = nil
+			continue
+		}
+
+		event, location, err := schedule.ParseOnCalendar(value)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		jobs = append(jobs, schedule.Job{Name: name, Schedule: event, Location: location})
+	}
+
+	return jobs, scanner.Err()
+}
+
+// yamlValue returns the scalar of a YAML key: value line, unquoted
+func yamlValue(line string) string {
+	_, value, _ := strings.Cut(line, ":")
+	return frontMatterValue(value)
+}
+
+// ReadCronJobs reads the schedule and timeZone of the Kubernetes CronJob manifests of a YAML file, named by their
+// metadata name. Documents without a schedule are skipped, and the schedule is read in the zone of the calendar
+// unless the CronJob sets timeZone or starts its schedule with CRON_TZ=.
+func ReadCronJobs(r io.Reader) ([]schedule.Job, error) {
+	var jobs []schedule.Job
+	var name, expr, zone string
+	exprLine, inMetadata := 0, false
+
+	flush := func() error {
+		defer func() { name, expr, zone, exprLine = "", "", "", 0 }()
+		if expr == "" {
+			return nil
+		}
+		if rest, ok := strings.CutPrefix(expr, "CRON_TZ="); ok {
+			zone, expr, _ = strings.Cut(rest, " ")
+		} else if rest, ok := strings.CutPrefix(expr, "TZ="); ok {
+			zone, expr, _ = strings.Cut(rest, " ")
+		}
+
+		job := schedule.Job{Name: name}
+		cron, err := schedule.ParseCron(expr)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", exprLine, err)
+		}
+		job.Schedule = cron
+		if zone != "" {
+			if job.Location, err = loadLocation(exprLine, zone); err != nil {
+				return err
+			}
+		}
+		jobs = append(jobs, job)
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		trimmed := strings.TrimSpace(text)
+		indented := strings.HasPrefix(text, " ")
+
+		switch {
+		case trimmed == "---":
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			inMetadata = false
+		case !indented && trimmed != "":
+			inMetadata = trimmed == "metadata:"
+		case inMetadata && name == "" && strings.HasPrefix(trimmed, "name:"):
+			name = yamlValue(trimmed)
+		case strings.HasPrefix(trimmed, "schedule:"):
+			expr, exprLine = yamlValue(trimmed), line
+		case strings.HasPrefix(trimmed, "timeZone:"):
+			zone = yamlValue(trimmed)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// formatFireTimes describes the times a job fires on a day, listing up to four of them, such as "03:00, 15:00",
+// or giving the first and last with their count, such as "00:00–23:00 ×24"
+func formatFireTimes(times []time.Time) string {
+	if len(times) > 4 {
+		return fmt.Sprintf("%s–%s ×%d", times[0].Format("15:04"), times[len(times)-1].Format("15:04"), len(times))
+	}
+	clocks := make([]string, len(times))
+	for i, t := range times {
+		clocks[i] = t.Format("15:04")
+	}
+	return strings.Join(clocks, ", ")
+}
+
+// JobAnnotator returns an annotator that marks the days the jobs fire in the time zone loc, with the number of
+// runs, such as "backup ×2", or their times when showTimes is set. The name is left out when there is a single job.
+// Only the days the calendar asks for are expanded.
+func JobAnnotator(jobs []schedule.Job, loc *time.Location, showTimes bool) calendar.Annotator {
+	return func(date time.Time) []calendar.Annotation {
+		start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+		end := start.AddDate(0, 0, 1)
+
+		var annotations []calendar.Annotation
+		for _, job := range jobs {
+			times := job.Times(start, end)
+			if len(times) == 0 {
+				continue
+			}
+
+			text := fmt.Sprintf("×%d", len(times))
+			if showTimes {
+				text = formatFireTimes(times)
+			}
+			if len(jobs) > 1 && job.Name != "" {
+				text = job.Name + " " + text
+			}
+			annotations = append(annotations, calendar.Annotation{Text: text})
+		}
+		return annotations
+	}
+}
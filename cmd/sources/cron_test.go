@@ -0,0 +1,143 @@
+package sources
+
+import (
+	"github.com/andre-a-alves/mdcal/cmd/calendar"
+	"github.com/andre-a-alves/mdcal/cmd/schedule"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// jobSummary describes jobs by name, time zone and the times they fire on 2025-03-14 in UTC, for comparison
+func jobSummary(jobs []schedule.Job) []string {
+	day := time.Date(2025, time.March, 14, 0, 0, 0, 0, time.UTC)
+	var summary []string
+	for _, job := range jobs {
+		zone := "calendar"
+		if job.Location != nil {
+			zone = job.Location.String()
+		}
+		summary = append(summary, job.Name+" ("+zone+"): "+formatFireTimes(job.Times(day, day.AddDate(0, 0, 1))))
+	}
+	return summary
+}
+
+func TestReadCrontab(t *testing.T) {
+	input := `# m h dom mon dow command
+SHELL=/bin/sh
+0 3 * * 1-5 /usr/local/bin/backup --full
+@reboot /usr/local/bin/warmup
+CRON_TZ=UTC
+@hourly rotate-logs
+`
+	jobs, err := ReadCrontab(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadCrontab() unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"/usr/local/bin/backup --full (calendar): 03:00",
+		"rotate-logs (UTC): 00:00–23:00 ×24",
+	}
+	if diff := cmp.Diff(expected, jobSummary(jobs)); diff != "" {
+		t.Errorf("ReadCrontab() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := ReadCrontab(strings.NewReader("\n0 3 * *\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("ReadCrontab() error = %v, want an error on line 2", err)
+	}
+}
+
+func TestReadTimer(t *testing.T) {
+	input := `[Unit]
+Description=Nightly backup
+
+[Timer]
+OnCalendar=Mon..Fri 03:00
+OnCalendar=Fri *-*-* 12:00 UTC
+Persistent=true
+`
+	jobs, err := ReadTimer(strings.NewReader(input), "backup")
+	if err != nil {
+		t.Fatalf("ReadTimer() unexpected error: %v", err)
+	}
+
+	expected := []string{"backup (calendar): 03:00", "backup (UTC): 12:00"}
+	if diff := cmp.Diff(expected, jobSummary(jobs)); diff != "" {
+		t.Errorf("ReadTimer() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadCronJobs(t *testing.T) {
+	input := `apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: report
+  labels:
+    name: ignored
+spec:
+  schedule: "30 6 * * *"
+  timeZone: "UTC"
+  jobTemplate:
+    spec:
+      template:
+        metadata:
+          name: pod
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+---
+kind: CronJob
+metadata:
+  name: cleanup
+spec:
+  schedule: "CRON_TZ=UTC 0 */6 * * *"
+`
+	jobs, err := ReadCronJobs(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadCronJobs() unexpected error: %v", err)
+	}
+
+	expected := []string{"report (UTC): 06:30", "cleanup (UTC): 00:00, 06:00, 12:00, 18:00"}
+	if diff := cmp.Diff(expected, jobSummary(jobs)); diff != "" {
+		t.Errorf("ReadCronJobs() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestJobAnnotator(t *testing.T) {
+	nightly, _ := schedule.ParseCron("0 3 * * *")
+	twice, _ := schedule.ParseCron("0 9,21 * * 1-5")
+	date := time.Date(2025, time.March, 14, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		jobs      []schedule.Job
+		showTimes bool
+		expected  []calendar.Annotation
+	}{
+		{
+			name:     "Single job count",
+			jobs:     []schedule.Job{{Name: "nightly", Schedule: nightly}},
+			expected: []calendar.Annotation{{Text: "×1"}},
+		},
+		{
+			name:      "Named jobs with times",
+			jobs:      []schedule.Job{{Name: "nightly", Schedule: nightly}, {Name: "sync", Schedule: twice}},
+			showTimes: true,
+			expected:  []calendar.Annotation{{Text: "nightly 03:00"}, {Text: "sync 09:00, 21:00"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := JobAnnotator(tt.jobs, time.UTC, tt.showTimes)(date)
+			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+				t.Errorf("JobAnnotator() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
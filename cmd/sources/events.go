@@ -0,0 +1,99 @@
+package sources
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/andre-a-alves/mdcal/cmd/calendar"
+	"github.com/andre-a-alves/mdcal/cmd/recurrence"
+	"io"
+	"strings"
+	"time"
+)
+
+// Event is an entry of an events file: a text on a single day or on the days of a recurrence rule
+type Event struct {
+	Text       string
+	Recurrence recurrence.Recurrence
+}
+
+// parseEventLine parses a line of the form DATE [RRULE:rule] [EXDATE:date,...] text
+func parseEventLine(line string) (Event, error) {
+	fields := strings.Fields(line)
+	start, err := recurrence.ParseDate(fields[0])
+	if err != nil {
+		return Event{}, err
+	}
+
+	event := Event{Recurrence: recurrence.Recurrence{Start: start}}
+	i := 1
+	for ; i < len(fields); i++ {
+		if value, ok := strings.CutPrefix(fields[i], "RRULE:"); ok {
+			rule, err := recurrence.ParseRule(value)
+			if err != nil {
+				return Event{}, err
+			}
+			event.Recurrence.Rule = &rule
+		} else if value, ok := strings.CutPrefix(fields[i], "EXDATE:"); ok {
+			for _, exdate := range strings.Split(value, ",") {
+				date, err := recurrence.ParseDate(exdate)
+				if err != nil {
+					return Event{}, err
+				}
+				event.Recurrence.Exdates = append(event.Recurrence.Exdates, date)
+			}
+		} else {
+			break
+		}
+	}
+
+	event.Text = strings.Join(fields[i:], " ")
+	if event.Text == "" {
+		return Event{}, fmt.Errorf("event on %s has no text", fields[0])
+	}
+	return event, nil
+}
+
+// ReadEvents reads an events file, with one event per line written as DATE [RRULE:rule] [EXDATE:date,...] text,
+// such as "2025-01-14 RRULE:FREQ=MONTHLY;BYDAY=2TU Team retro". Blank lines and lines starting with # are skipped.
+func ReadEvents(r io.Reader) ([]Event, error) {
+	var events []Event
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		event, err := parseEventLine(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		events = append(events, event)
+	}
+
+	return events, scanner.Err()
+}
+
+// EventAnnotator returns an annotator that places the events on their days. Recurring events are expanded a month
+// at a time, and only for the months of the days the calendar asks for. Only the last month expanded is kept, so
+// that calendars of long ranges, which ask for their days in order, use bounded memory.
+func EventAnnotator(events []Event) calendar.Annotator {
+	var month time.Time
+	var days map[string][]calendar.Annotation
+
+	return func(date time.Time) []calendar.Annotation {
+		first := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+		if days == nil || !first.Equal(month) {
+			month, days = first, make(map[string][]calendar.Annotation)
+			for _, event := range events {
+				for _, day := range event.Recurrence.Between(first, first.AddDate(0, 1, -1)) {
+					key := day.Format(time.DateOnly)
+					days[key] = append(days[key], calendar.Annotation{Text: event.Text})
+				}
+			}
+		}
+
+		return days[date.Format(time.DateOnly)]
+	}
+}
@@ -0,0 +1,66 @@
+package sources
+
+import (
+	"github.com/andre-a-alves/mdcal/cmd/calendar"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEventAnnotator(t *testing.T) {
+	input := `# Team ceremonies
+2025-03-14 Release party
+2025-01-14 RRULE:FREQ=MONTHLY;BYDAY=2TU Team retro
+2025-01-03 RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=FR;UNTIL=20250630 EXDATE:2025-03-28 Sprint demo
+`
+	events, err := ReadEvents(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadEvents() unexpected error: %v", err)
+	}
+	annotator := EventAnnotator(events)
+
+	tests := []struct {
+		date     string
+		expected []calendar.Annotation
+	}{
+		{date: "2025-03-11", expected: []calendar.Annotation{{Text: "Team retro"}}},
+		{date: "2025-03-14", expected: []calendar.Annotation{{Text: "Release party"}, {Text: "Sprint demo"}}},
+		{date: "2025-03-28"},
+		{date: "2025-07-11"},
+		// Months asked for again are expanded again
+		{date: "2025-03-14", expected: []calendar.Annotation{{Text: "Release party"}, {Text: "Sprint demo"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.date, func(t *testing.T) {
+			date, _ := time.Parse(time.DateOnly, tt.date)
+			if diff := cmp.Diff(tt.expected, annotator(date)); diff != "" {
+				t.Errorf("EventAnnotator() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReadEventsErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expectedErr string
+	}{
+		{name: "Invalid date", input: "2025-13-01 Party", expectedErr: "line 1: invalid date"},
+		{name: "Invalid rule", input: "\n2025-03-01 RRULE:FREQ=SOMETIMES Party", expectedErr: "line 2: unsupported FREQ"},
+		{name: "Invalid exception", input: "2025-03-01 RRULE:FREQ=DAILY EXDATE:soon Party", expectedErr: "line 1: invalid date"},
+		{name: "No text", input: "2025-03-01 RRULE:FREQ=DAILY", expectedErr: "line 1: event on 2025-03-01 has no text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadEvents(strings.NewReader(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+				t.Errorf("ReadEvents() error = %v, want %q", err, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package sources
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"github.com/andre-a-alves/mdcal/cmd/calendar"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// runGit runs git with args in the repository at repo and passes its standard output to parse as it is produced
+func runGit(repo string, args []string, parse func(r io.Reader) error) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", append([]string{"-C", repo}, args...)...)
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	parseErr := parse(stdout)
+	// Drain the rest of the output so that git is not blocked writing to the pipe
+	_, _ = io.Copy(io.Discard, stdout)
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return parseErr
+}
+
+// gitDateArgs limits git log to the days between start and end. The limits are widened by a day because
+// git compares them in the local time zone, and the dates are checked again while counting.
+func gitDateArgs(start time.Time, end time.Time) []string {
+	return []string{
+		"--since=" + start.AddDate(0, 0, -1).Format(time.DateOnly),
+		"--until=" + end.AddDate(0, 0, 2).Format(time.DateOnly),
+	}
+}
+
+// GitCommitCounts counts the commits reachable from HEAD in the repository at repo per committer date between
+// start and end, limited to commits whose author matches one of authors when any are given. The history is
+// streamed from git log, so repositories with many thousands of commits are counted without holding the log in memory.
+func GitCommitCounts(repo string, authors []string, start time.Time, end time.Time) (map[string]int, error) {
+	args := append([]string{"log", "--format=%cs"}, gitDateArgs(start, end)...)
+	for _, author := range authors {
+		args = append(args, "--author="+author)
+	}
+
+	var counts map[string]int
+	err := runGit(repo, args, func(r io.Reader) error {
+		var err error
+		counts, err = countCommitDates(r, start, end)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// countCommitDates counts the YYYY-MM-DD dates, one per line, that fall between start and end
+func countCommitDates(r io.Reader, start time.Time, end time.Time) (map[string]int, error) {
+	first, last := start.Format(time.DateOnly), end.Format(time.DateOnly)
+
+	counts := make(map[string]int)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		// Dates in YYYY-MM-DD form sort as strings
+		date := strings.TrimSpace(scanner.Text())
+		if date < first || date > last {
+			continue
+		}
+		counts[date]++
+	}
+
+	return counts, scanner.Err()
+}
+
+// CommitAnnotations turns commit counts into annotations such as "3 commits"
+func CommitAnnotations(counts map[string]int) map[string][]calendar.Annotation {
+	annotations := make(map[string][]calendar.Annotation, len(counts))
+	for date, count := range counts {
+		text := fmt.Sprintf("%d commits", count)
+		if count == 1 {
+			text = "1 commit"
+		}
+		annotations[date] = []calendar.Annotation{{Text: text}}
+	}
+
+	return annotations
+}
+
+// GitTags returns an annotation for every tag of the repository at repo created between start and end.
+// Annotated tags are placed on their tagger date and lightweight tags on the date of their commit.
+func GitTags(repo string, start time.Time, end time.Time) (map[string][]calendar.Annotation, error) {
+	args := []string{"for-each-ref", "--sort=creatordate", "--format=%(creatordate:short) %(refname:short)", "refs/tags"}
+
+	var tags map[string][]calendar.Annotation
+	err := runGit(repo, args, func(r io.Reader) error {
+		var err error
+		tags, err = parseTags(r, start, end)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// parseTags parses lines of a YYYY-MM-DD date followed by a tag name, keeping the tags between start and end
+func parseTags(r io.Reader, start time.Time, end time.Time) (map[string][]calendar.Annotation, error) {
+	first, last := start.Format(time.DateOnly), end.Format(time.DateOnly)
+
+	tags := make(map[string][]calendar.Annotation)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		date, name, found := strings.Cut(strings.TrimSpace(scanner.Text()), " ")
+		if !found || date < first || date > last {
+			continue
+		}
+		tags[date] = append(tags[date], calendar.Annotation{Text: "🏷 " + name})
+	}
+
+	return tags, scanner.Err()
+}
@@ -0,0 +1,107 @@
+package sources
+
+import (
+	"github.com/andre-a-alves/mdcal/cmd/calendar"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var (
+	march1  = time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	march31 = time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC)
+)
+
+func TestCountCommitDates(t *testing.T) {
+	input := "2025-04-01\n2025-03-14\n2025-03-14\n2025-03-01\n2025-02-28\n"
+
+	actual, err := countCommitDates(strings.NewReader(input), march1, march31)
+	if err != nil {
+		t.Fatalf("countCommitDates() unexpected error: %v", err)
+	}
+
+	expected := map[string]int{"2025-03-14": 2, "2025-03-01": 1}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("countCommitDates() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseTags(t *testing.T) {
+	input := "2025-02-01 v0.9.0\n2025-03-14 v1.0.0\n2025-03-14 release/1.0\n"
+
+	actual, err := parseTags(strings.NewReader(input), march1, march31)
+	if err != nil {
+		t.Fatalf("parseTags() unexpected error: %v", err)
+	}
+
+	expected := map[string][]calendar.Annotation{
+		"2025-03-14": {{Text: "🏷 v1.0.0"}, {Text: "🏷 release/1.0"}},
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("parseTags() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCommitAnnotations(t *testing.T) {
+	actual := CommitAnnotations(map[string]int{"2025-03-14": 1, "2025-03-15": 4})
+
+	expected := map[string][]calendar.Annotation{
+		"2025-03-14": {{Text: "1 commit"}},
+		"2025-03-15": {{Text: "4 commits"}},
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("CommitAnnotations() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGitRepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	repo := t.TempDir()
+	git := func(date string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", repo}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_DATE="+date+"T12:00:00Z", "GIT_COMMITTER_DATE="+date+"T12:00:00Z",
+			"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_NOSYSTEM=1")
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, output)
+		}
+	}
+	commit := func(date string, author string) {
+		git(date, "-c", "user.name="+author, "-c", "user.email="+author+"@example.com",
+			"commit", "--allow-empty", "-q", "-m", "work")
+	}
+
+	git("2025-03-01", "init", "-q")
+	commit("2025-03-03", "alice")
+	commit("2025-03-03", "bob")
+	commit("2025-03-04", "alice")
+	git("2025-03-04", "tag", "v1.0.0")
+
+	counts, err := GitCommitCounts(repo, []string{"alice"}, march1, march31)
+	if err != nil {
+		t.Fatalf("GitCommitCounts() unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(map[string]int{"2025-03-03": 1, "2025-03-04": 1}, counts); diff != "" {
+		t.Errorf("GitCommitCounts() mismatch (-want +got):\n%s", diff)
+	}
+
+	tags, err := GitTags(repo, march1, march31)
+	if err != nil {
+		t.Fatalf("GitTags() unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(map[string][]calendar.Annotation{"2025-03-04": {{Text: "🏷 v1.0.0"}}}, tags); diff != "" {
+		t.Errorf("GitTags() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := GitCommitCounts(t.TempDir(), nil, march1, march31); err == nil {
+		t.Error("GitCommitCounts() expected an error outside a repository")
+	}
+}
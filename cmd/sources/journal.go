@@ -0,0 +1,53 @@
+package sources
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Journal links the days of the calendar to their daily notes
+type Journal struct {
+	Dir     string // directory of the daily notes
+	Pattern string // time layout of the note of a day, relative to Dir, such as 2006-01-02.md
+	Base    string // directory the links are relative to, normally that of the output file
+	Missing string // mark appended to days without a note, if set
+}
+
+// ExpandHome replaces a leading ~ in path with the home directory of the current user
+func ExpandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
+
+// DayLabel returns a calendar.Options.DayLabel function that turns the text of every day with a note into
+// a markdown link to the note, relative to Base, and appends the Missing mark to the other days
+func (j Journal) DayLabel() (func(date time.Time, text string) string, error) {
+	dir := ExpandHome(j.Dir)
+	if _, err := relativeLink(j.Base, dir); err != nil {
+		return nil, err
+	}
+
+	return func(date time.Time, text string) string {
+		note := filepath.Join(dir, filepath.FromSlash(date.Format(j.Pattern)))
+		if info, err := os.Stat(note); err != nil || info.IsDir() {
+			if j.Missing == "" {
+				return text
+			}
+			return text + " " + j.Missing
+		}
+
+		link, err := relativeLink(j.Base, note)
+		if err != nil {
+			return text
+		}
+		return "[" + text + "](" + link + ")"
+	}, nil
+}
@@ -0,0 +1,55 @@
+package sources
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestJournalDayLabel(t *testing.T) {
+	root := t.TempDir()
+	notes := filepath.Join(root, "notes", "daily log")
+	if err := os.MkdirAll(filepath.Join(notes, "2025"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(notes, "2025", "2025-03-14.md"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		journal  Journal
+		expected []string
+	}{
+		{
+			name:     "Output next to the notes",
+			journal:  Journal{Dir: notes, Pattern: "2006/2006-01-02.md", Base: filepath.Join(root, "notes")},
+			expected: []string{"13", "[14](daily%20log/2025/2025-03-14.md)"},
+		},
+		{
+			name:     "Output in a sibling directory, missing days marked",
+			journal:  Journal{Dir: notes, Pattern: "2006/2006-01-02.md", Base: filepath.Join(root, "calendars"), Missing: "✗"},
+			expected: []string{"13 ✗", "[14](../notes/daily%20log/2025/2025-03-14.md)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dayLabel, err := tt.journal.DayLabel()
+			if err != nil {
+				t.Fatalf("DayLabel() unexpected error: %v", err)
+			}
+
+			actual := []string{
+				dayLabel(time.Date(2025, time.March, 13, 0, 0, 0, 0, time.UTC), "13"),
+				dayLabel(time.Date(2025, time.March, 14, 0, 0, 0, 0, time.UTC), "14"),
+			}
+			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+				t.Errorf("DayLabel() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
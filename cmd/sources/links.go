@@ -0,0 +1,26 @@
+package sources
+
+import (
+	"net/url"
+	"path/filepath"
+)
+
+// relativeLink returns the markdown link target of the file at target, relative to the directory base,
+// with forward slashes and escaped spaces so that it works as a link in any markdown viewer
+func relativeLink(base string, target string) (string, error) {
+	absBase, err := filepath.Abs(base)
+	if err != nil {
+		return "", err
+	}
+	absTarget, err := filepath.Abs(target)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(absBase, absTarget)
+	if err != nil {
+		return "", err
+	}
+
+	link := &url.URL{Path: filepath.ToSlash(rel)}
+	return link.EscapedPath(), nil
+}
@@ -0,0 +1,240 @@
+package sources
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/andre-a-alves/mdcal/cmd/calendar"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OrgRepeater is the repeater of an Org timestamp, such as +1w
+type OrgRepeater struct {
+	Count int  // zero when the timestamp does not repeat
+	Unit  byte // h, d, w, m or y
+}
+
+// OrgEntry is an Org headline placed on a day by a SCHEDULED or DEADLINE timestamp
+type OrgEntry struct {
+	File     string
+	Line     int    // line number of the headline, starting at 1
+	Keyword  string // TODO keyword, such as TODO or DONE; empty when the headline has none
+	Done     bool   // whether Keyword is one of the done keywords of the file
+	Title    string
+	Tags     []string // tags of the headline, its parents and the file
+	Deadline bool     // whether the timestamp is a DEADLINE rather than SCHEDULED
+	Date     time.Time
+	Time     string // start time of the timestamp, such as 10:00; empty when it has none
+	Repeat   OrgRepeater
+}
+
+// OccursOn reports whether the entry falls on date, following its repeater from its first date onwards. Monthly and
+// yearly repeaters of a day that a month lacks, such as the 31st or February 29, fall on the last day of that month.
+func (e OrgEntry) OccursOn(date time.Time) bool {
+	if date.Before(e.Date) {
+		return false
+	}
+	if e.Repeat.Count == 0 {
+		return date.Equal(e.Date)
+	}
+
+	days := int(date.Sub(e.Date).Hours() / 24)
+	months := (date.Year()-e.Date.Year())*12 + int(date.Month()) - int(e.Date.Month())
+	day := min(e.Date.Day(), time.Date(date.Year(), date.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day())
+	switch e.Repeat.Unit {
+	case 'd':
+		return days%e.Repeat.Count == 0
+	case 'w':
+		return days%(7*e.Repeat.Count) == 0
+	case 'm':
+		return date.Day() == day && months%e.Repeat.Count == 0
+	case 'y':
+		return date.Day() == day && date.Month() == e.Date.Month() && (date.Year()-e.Date.Year())%e.Repeat.Count == 0
+	}
+	// Hourly repeaters fire every day
+	return true
+}
+
+var (
+	// orgHeadlinePattern matches a headline, capturing its text and its trailing tags, such as ":work:urgent:"
+	orgHeadlinePattern = regexp.MustCompile(`^(\*+)\s+(.*?)(?:\s+(:[\w@#%:]+:))?\s*$`)
+	// orgPlanningPattern matches a SCHEDULED or DEADLINE timestamp, capturing its date, time and repeater
+	orgPlanningPattern = regexp.MustCompile(`(SCHEDULED|DEADLINE):\s*<(\d{4}-\d{2}-\d{2})(?:\s+[^\s\d>]+)?(?:\s+(\d{1,2}:\d{2})[^\s>]*)?(?:\s+(?:\.\+|\+\+|\+)(\d+)([hdwmy]))?[^>]*>`)
+	// orgPlanningLinePattern matches the start of a planning line
+	orgPlanningLinePattern = regexp.MustCompile(`^(?:SCHEDULED|DEADLINE|CLOSED):`)
+	// orgKeywordsPattern matches the in-buffer settings that declare the TODO keywords of a file
+	orgKeywordsPattern = regexp.MustCompile(`^#\+(?:SEQ_|TYP_)?TODO:\s*(.*)$`)
+	// orgFileTagsPattern matches the in-buffer setting of the tags shared by all headlines of a file
+	orgFileTagsPattern = regexp.MustCompile(`^#\+FILETAGS:\s*(.*)$`)
+)
+
+// orgKeywords holds the TODO keywords of a file, by whether they mark the headline as done
+type orgKeywords map[string]bool
+
+// add declares the keywords of a #+TODO line, such as "TODO NEXT(n) | DONE CANCELED(c@)"
+func (k orgKeywords) add(line string) {
+	words := strings.Fields(line)
+	bar := slices.Index(words, "|")
+	for i, word := range words {
+		if word == "|" {
+			continue
+		}
+		name, _, _ := strings.Cut(word, "(")
+		// Without a bar, only the last keyword marks a headline as done
+		k[name] = (bar >= 0 && i > bar) || (bar < 0 && i == len(words)-1)
+	}
+}
+
+// splitOrgTags splits tags written as ":work:urgent:"
+func splitOrgTags(tags string) []string {
+	return strings.FieldsFunc(tags, func(r rune) bool { return r == ':' })
+}
+
+// ReadOrg reads the headlines of an Org file with a SCHEDULED or DEADLINE timestamp on their planning line,
+// recording file as their location. Headlines with both timestamps produce an entry for each.
+func ReadOrg(r io.Reader, file string) ([]OrgEntry, error) {
+	var entries []OrgEntry
+	keywords := orgKeywords{}
+	var fileTags []string
+	// Tags of the enclosing headlines, by level, which the headlines below them inherit
+	var parentTags [][]string
+
+	var headline *OrgEntry
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+
+		if match := orgKeywordsPattern.FindStringSubmatch(text); match != nil {
+			keywords.add(match[1])
+			continue
+		}
+		if match := orgFileTagsPattern.FindStringSubmatch(text); match != nil {
+			fileTags = append(fileTags, splitOrgTags(match[1])...)
+			continue
+		}
+
+		if match := orgHeadlinePattern.FindStringSubmatch(text); match != nil {
+			if len(keywords) == 0 {
+				keywords.add("TODO | DONE")
+			}
+			level := len(match[1])
+			for len(parentTags) < level-1 {
+				parentTags = append(parentTags, nil)
+			}
+			parentTags = append(parentTags[:level-1], splitOrgTags(match[3]))
+
+			entry := OrgEntry{File: file, Line: line, Title: match[2], Tags: slices.Clone(fileTags)}
+			for _, levelTags := range parentTags {
+				for _, tag := range levelTags {
+					if !slices.Contains(entry.Tags, tag) {
+						entry.Tags = append(entry.Tags, tag)
+					}
+				}
+			}
+			keyword, title, _ := strings.Cut(match[2], " ")
+			if done, ok := keywords[keyword]; ok {
+				entry.Keyword, entry.Done, entry.Title = keyword, done, strings.TrimSpace(title)
+			}
+			headline = &entry
+			continue
+		}
+
+		// The planning line directly follows its headline and holds nothing but timestamps
+		if planning := strings.TrimSpace(text); headline != nil && orgPlanningLinePattern.MatchString(planning) {
+			for _, match := range orgPlanningPattern.FindAllStringSubmatch(text, -1) {
+				date, err := time.Parse(time.DateOnly, match[2])
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %w", line, err)
+				}
+				entry := *headline
+				entry.Deadline = match[1] == "DEADLINE"
+				entry.Date = date
+				entry.Time = match[3]
+				if count, _ := strconv.Atoi(match[4]); count > 0 {
+					entry.Repeat = OrgRepeater{Count: count, Unit: match[5][0]}
+				}
+				entries = append(entries, entry)
+			}
+		}
+		headline = nil
+	}
+
+	return entries, scanner.Err()
+}
+
+// OrgFilter selects the Org entries shown in the calendar
+type OrgFilter struct {
+	Keywords []string // keep headlines with one of these TODO keywords
+	Tags     []string // keep headlines with one of these tags, including inherited ones
+	ShowDone bool     // keep headlines with a done keyword, struck through
+}
+
+// matches reports whether the entry passes the filter
+func (f OrgFilter) matches(entry OrgEntry) bool {
+	if entry.Done && !f.ShowDone {
+		return false
+	}
+	if len(f.Keywords) > 0 && !slices.Contains(f.Keywords, entry.Keyword) {
+		return false
+	}
+	if len(f.Tags) > 0 && !slices.ContainsFunc(entry.Tags, func(tag string) bool {
+		return slices.ContainsFunc(f.Tags, func(wanted string) bool { return strings.EqualFold(tag, wanted) })
+	}) {
+		return false
+	}
+	return true
+}
+
+// OrgAnnotator returns an annotator that places the entries passing the filter on the days they occur,
+// such as "Deadline: TODO Ship release :work:". Repeating entries are only expanded for the days that are
+// rendered. Entries are linked to their headline, relative to the directory base.
+func OrgAnnotator(entries []OrgEntry, base string, filter OrgFilter) (calendar.Annotator, error) {
+	type placed struct {
+		entry      OrgEntry
+		annotation calendar.Annotation
+	}
+
+	var shown []placed
+	for _, entry := range entries {
+		if !filter.matches(entry) {
+			continue
+		}
+
+		text := entry.Title
+		if entry.Keyword != "" {
+			text = entry.Keyword + " " + text
+		}
+		if len(entry.Tags) > 0 {
+			text += " :" + strings.Join(entry.Tags, ":") + ":"
+		}
+		if entry.Done {
+			text = "~~" + text + "~~"
+		}
+		if entry.Time != "" {
+			text = entry.Time + " " + text
+		}
+		if entry.Deadline {
+			text = "Deadline: " + text
+		}
+
+		link, err := relativeLink(base, entry.File)
+		if err != nil {
+			return nil, err
+		}
+		shown = append(shown, placed{entry: entry, annotation: calendar.Annotation{Text: text, Link: fmt.Sprintf("%s#L%d", link, entry.Line)}})
+	}
+
+	return func(date time.Time) []calendar.Annotation {
+		var annotations []calendar.Annotation
+		for _, p := range shown {
+			if p.entry.OccursOn(date) {
+				annotations = append(annotations, p.annotation)
+			}
+		}
+		return annotations
+	}, nil
+}
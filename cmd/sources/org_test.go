@@ -0,0 +1,152 @@
+package sources
+
+import (
+	"github.com/andre-a-alves/mdcal/cmd/calendar"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const orgInput = `#+TODO: TODO NEXT | DONE CANCELED
+#+FILETAGS: :team:
+* Releases                                                          :work:
+** NEXT Ship release
+   DEADLINE: <2025-03-14 Fri -3d> SCHEDULED: <2025-03-10 Mon>
+** DONE Write notes
+   SCHEDULED: <2025-03-03 Mon 10:00-11:00>
+* Standup                                                        :meeting:
+  SCHEDULED: <2025-03-04 Tue 09:30 +1w>
+* Not planned
+  Body text mentioning SCHEDULED: <2025-03-05 Wed>
+`
+
+func TestReadOrg(t *testing.T) {
+	march := func(day int) time.Time {
+		return time.Date(2025, time.March, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	actual, err := ReadOrg(strings.NewReader(orgInput), "plan.org")
+	if err != nil {
+		t.Fatalf("ReadOrg() unexpected error: %v", err)
+	}
+
+	expected := []OrgEntry{
+		{File: "plan.org", Line: 4, Keyword: "NEXT", Title: "Ship release", Tags: []string{"team", "work"}, Deadline: true, Date: march(14)},
+		{File: "plan.org", Line: 4, Keyword: "NEXT", Title: "Ship release", Tags: []string{"team", "work"}, Date: march(10)},
+		{File: "plan.org", Line: 6, Keyword: "DONE", Done: true, Title: "Write notes", Tags: []string{"team", "work"}, Date: march(3), Time: "10:00"},
+		{File: "plan.org", Line: 8, Title: "Standup", Tags: []string{"team", "meeting"}, Date: march(4), Time: "09:30", Repeat: OrgRepeater{Count: 1, Unit: 'w'}},
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("ReadOrg() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestOrgEntryOccursOn(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	start := date(2025, time.January, 31)
+	leapDay := date(2024, time.February, 29)
+
+	tests := []struct {
+		name     string
+		start    time.Time // the first date of the entry; start when zero
+		repeat   OrgRepeater
+		date     time.Time
+		expected bool
+	}{
+		{name: "Same day without repeater", date: start, expected: true},
+		{name: "Other day without repeater", date: date(2025, time.February, 1)},
+		{name: "Every other day", repeat: OrgRepeater{Count: 2, Unit: 'd'}, date: date(2025, time.February, 2), expected: true},
+		{name: "Every other day, off day", repeat: OrgRepeater{Count: 2, Unit: 'd'}, date: date(2025, time.February, 3)},
+		{name: "Every two weeks", repeat: OrgRepeater{Count: 2, Unit: 'w'}, date: date(2025, time.February, 28), expected: true},
+		{name: "Before the first date", repeat: OrgRepeater{Count: 1, Unit: 'w'}, date: date(2025, time.January, 24)},
+		{name: "Monthly", repeat: OrgRepeater{Count: 1, Unit: 'm'}, date: date(2025, time.March, 31), expected: true},
+		{name: "Monthly, end of a shorter month", repeat: OrgRepeater{Count: 1, Unit: 'm'}, date: date(2025, time.February, 28), expected: true},
+		{name: "Monthly, before the end of a shorter month", repeat: OrgRepeater{Count: 1, Unit: 'm'}, date: date(2025, time.April, 29)},
+		{name: "Monthly, end of a leap February", repeat: OrgRepeater{Count: 1, Unit: 'm'}, date: date(2028, time.February, 29), expected: true},
+		{name: "Monthly, day before the end of a leap February", repeat: OrgRepeater{Count: 1, Unit: 'm'}, date: date(2028, time.February, 28)},
+		{name: "Quarterly, off month", repeat: OrgRepeater{Count: 3, Unit: 'm'}, date: date(2025, time.March, 31)},
+		{name: "Yearly", repeat: OrgRepeater{Count: 1, Unit: 'y'}, date: date(2026, time.January, 31), expected: true},
+		{name: "Leap day, common year", start: leapDay, repeat: OrgRepeater{Count: 1, Unit: 'y'}, date: date(2025, time.February, 28), expected: true},
+		{name: "Leap day, not on March 1", start: leapDay, repeat: OrgRepeater{Count: 1, Unit: 'y'}, date: date(2025, time.March, 1)},
+		{name: "Leap day, leap year", start: leapDay, repeat: OrgRepeater{Count: 1, Unit: 'y'}, date: date(2028, time.February, 29), expected: true},
+		{name: "Leap day, not on February 28 of a leap year", start: leapDay, repeat: OrgRepeater{Count: 1, Unit: 'y'}, date: date(2028, time.February, 28)},
+		{name: "Leap day monthly", start: leapDay, repeat: OrgRepeater{Count: 1, Unit: 'm'}, date: date(2024, time.March, 29), expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := OrgEntry{Date: start, Repeat: tt.repeat}
+			if !tt.start.IsZero() {
+				entry.Date = tt.start
+			}
+			if actual := entry.OccursOn(tt.date); actual != tt.expected {
+				t.Errorf("OccursOn(%s) = %v, want %v", tt.date.Format(time.DateOnly), actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestOrgAnnotator(t *testing.T) {
+	entries, err := ReadOrg(strings.NewReader(orgInput), "/notes/plan.org")
+	if err != nil {
+		t.Fatalf("ReadOrg() unexpected error: %v", err)
+	}
+	march := func(day int) time.Time {
+		return time.Date(2025, time.March, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		filter   OrgFilter
+		date     time.Time
+		expected []calendar.Annotation
+	}{
+		{
+			name:     "Deadline",
+			date:     march(14),
+			expected: []calendar.Annotation{{Text: "Deadline: NEXT Ship release :team:work:", Link: "plan.org#L4"}},
+		},
+		{
+			name:     "Repeated",
+			date:     march(18),
+			expected: []calendar.Annotation{{Text: "09:30 Standup :team:meeting:", Link: "plan.org#L8"}},
+		},
+		{
+			name: "Done hidden",
+			date: march(3),
+		},
+		{
+			name:     "Done shown",
+			filter:   OrgFilter{ShowDone: true},
+			date:     march(3),
+			expected: []calendar.Annotation{{Text: "10:00 ~~DONE Write notes :team:work:~~", Link: "plan.org#L6"}},
+		},
+		{
+			name:   "Keyword filter",
+			filter: OrgFilter{Keywords: []string{"TODO"}},
+			date:   march(14),
+		},
+		{
+			name:     "Tag filter",
+			filter:   OrgFilter{Tags: []string{"Meeting"}},
+			date:     march(11),
+			expected: []calendar.Annotation{{Text: "09:30 Standup :team:meeting:", Link: "plan.org#L8"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotator, err := OrgAnnotator(entries, "/notes", tt.filter)
+			if err != nil {
+				t.Fatalf("OrgAnnotator() unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.expected, annotator(tt.date)); diff != "" {
+				t.Errorf("OrgAnnotator() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
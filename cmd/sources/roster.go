@@ -0,0 +1,232 @@
+package sources
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/andre-a-alves/mdcal/cmd/calendar"
+	"github.com/andre-a-alves/mdcal/cmd/utils"
+	"io"
+	"strings"
+	"time"
+)
+
+// rosterMember is a member of a roster as read, before the holidays of their country are looked up
+type rosterMember struct {
+	member  calendar.Member
+	country string
+	line    int
+}
+
+// indentation returns the number of leading spaces of a line
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// yamlList returns the items of a YAML flow list such as [mon, tue], or the value itself when it is not a list
+func yamlList(value string) []string {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, "[") || !strings.HasSuffix(value, "]") {
+		return []string{frontMatterValue(value)}
+	}
+
+	var items []string
+	for _, item := range strings.Split(value[1:len(value)-1], ",") {
+		if item = frontMatterValue(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseWorkdays parses working days such as "mon", "mon-fri" or "mon, wed, fri", where ranges may wrap around
+// the end of the week, such as sun-thu
+func parseWorkdays(value string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		firstName, lastName, isRange := strings.Cut(field, "-")
+		first, ok := utils.LookupWeekday(strings.TrimSpace(firstName))
+		last := first
+		if isRange && ok {
+			last, ok = utils.LookupWeekday(strings.TrimSpace(lastName))
+		}
+		if !ok {
+			return nil, fmt.Errorf("invalid workdays %q: expected weekdays such as mon-fri or mon, wed, fri", value)
+		}
+
+		for day := first; ; day = (day + 1) % 7 {
+			days = append(days, day)
+			if day == last {
+				break
+			}
+		}
+	}
+	return days, nil
+}
+
+// parseRosterDays parses a date or a range of days of the roster, as read by utils.ParseDays
+func parseRosterDays(value string) ([]string, error) {
+	days, err := utils.ParseDays(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q: expected YYYY-MM-DD or YYYY-MM-DD..YYYY-MM-DD", value)
+	}
+	return days, nil
+}
+
+// ReadRoster reads the members of a team from a YAML roster, such as
+//
+//	holidays:
+//	  DE:
+//	    - 2025-10-03: German Unity Day
+//	members:
+//	  - name: Alice
+//	    country: DE
+//	    workdays: mon-thu
+//	    pto: [2025-03-10..2025-03-14, 2025-04-22]
+//
+// Members work Monday to Friday unless workdays is set, and get the holidays listed for their country. Only this
+// layout is read, as a block or flow list, and other keys are skipped.
+func ReadRoster(r io.Reader) ([]calendar.Member, error) {
+	var members []rosterMember
+	holidays := make(map[string]map[string]string)
+	var section, country, listKey string
+	memberIndent := -1
+
+	// addItem adds an item of the list of key, as written, to the current member or country
+	addItem := func(key string, item string) error {
+		switch {
+		case section == "holidays":
+			date, name, _ := strings.Cut(item, ":")
+			days, err := parseRosterDays(frontMatterValue(date))
+			if err != nil {
+				return err
+			}
+			for _, day := range days {
+				holidays[country][day] = frontMatterValue(name)
+			}
+		case key == "workdays":
+			days, err := parseWorkdays(frontMatterValue(item))
+			if err != nil {
+				return err
+			}
+			member := &members[len(members)-1].member
+			member.Workdays = append(member.Workdays, days...)
+		case key == "pto":
+			days, err := parseRosterDays(frontMatterValue(item))
+			if err != nil {
+				return err
+			}
+			for _, day := range days {
+				members[len(members)-1].member.Away[day] = true
+			}
+		}
+		return nil
+	}
+
+	// setKey handles a key: value line of the current member or country
+	setKey := func(key string, value string) error {
+		listKey = ""
+		if section == "holidays" {
+			country = strings.ToUpper(key)
+			if holidays[country] == nil {
+				holidays[country] = make(map[string]string)
+			}
+			if value == "" {
+				listKey = key
+				return nil
+			}
+			for _, item := range yamlList(value) {
+				if err := addItem(key, item); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		if len(members) == 0 {
+			return errors.New("expected a list of members")
+		}
+		current := &members[len(members)-1]
+		switch key {
+		case "name":
+			current.member.Name = frontMatterValue(value)
+		case "country":
+			current.country = strings.ToUpper(frontMatterValue(value))
+		case "workdays", "pto":
+			if key == "workdays" {
+				// Workdays given explicitly replace Monday to Friday, even when the list is empty
+				current.member.Workdays = []time.Weekday{}
+			}
+			if value == "" {
+				listKey = key
+				return nil
+			}
+			for _, item := range yamlList(value) {
+				if err := addItem(key, item); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), " \t\r")
+		trimmed := strings.TrimSpace(text)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || trimmed == "---" {
+			continue
+		}
+		indent := indentation(text)
+
+		if indent == 0 && !strings.HasPrefix(trimmed, "- ") {
+			key, _, _ := strings.Cut(trimmed, ":")
+			section, country, listKey = strings.TrimSpace(key), "", ""
+			continue
+		}
+		if section != "holidays" && section != "members" {
+			continue
+		}
+
+		var err error
+		item, isItem := strings.CutPrefix(trimmed, "- ")
+		switch {
+		case section == "members" && isItem && (memberIndent < 0 || indent <= memberIndent):
+			// A list item at the level of the members starts the next member
+			memberIndent = indent
+			members = append(members, rosterMember{member: calendar.Member{Away: make(map[string]bool)}, line: line})
+			key, value, _ := strings.Cut(item, ":")
+			err = setKey(strings.TrimSpace(key), strings.TrimSpace(value))
+		case isItem:
+			if listKey == "" {
+				err = errors.New("unexpected list item")
+			} else {
+				err = addItem(listKey, item)
+			}
+		default:
+			key, value, _ := strings.Cut(trimmed, ":")
+			err = setKey(strings.TrimSpace(key), strings.TrimSpace(value))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	team := make([]calendar.Member, 0, len(members))
+	for _, m := range members {
+		if m.member.Name == "" {
+			return nil, fmt.Errorf("line %d: member without a name", m.line)
+		}
+		m.member.Holidays = holidays[m.country]
+		team = append(team, m.member)
+	}
+	return team, nil
+}
@@ -0,0 +1,107 @@
+package sources
+
+import (
+	"github.com/andre-a-alves/mdcal/cmd/calendar"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseWorkdays(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected []time.Weekday
+		wantErr  bool
+	}{
+		{name: "Range", value: "mon-thu", expected: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday}},
+		{name: "Range across the weekend", value: "sat-mon", expected: []time.Weekday{time.Saturday, time.Sunday, time.Monday}},
+		{name: "List", value: "Mon, wednesday,fri", expected: []time.Weekday{time.Monday, time.Wednesday, time.Friday}},
+		{name: "Unknown day", value: "mon-fry", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseWorkdays(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseWorkdays() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.expected, got); diff != "" {
+				t.Errorf("parseWorkdays() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReadRoster(t *testing.T) {
+	input := `# Platform team
+holidays:
+  DE:
+    - 2025-04-18: Good Friday
+    - "2025-04-21": Easter Monday
+  us: [2025-05-26]
+members:
+  - name: Alice
+    country: de
+    email: alice@example.com
+    workdays: mon-thu
+    pto:
+      - 2025-04-07..2025-04-09  # ski trip
+  - name: "Bob"
+    country: US
+    pto: [2025-04-25]
+  - country: FR
+    name: Chen
+    workdays: [sun-tue, thu]
+`
+	members, err := ReadRoster(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadRoster() unexpected error: %v", err)
+	}
+
+	expected := []calendar.Member{
+		{
+			Name:     "Alice",
+			Workdays: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday},
+			Holidays: map[string]string{"2025-04-18": "Good Friday", "2025-04-21": "Easter Monday"},
+			Away:     map[string]bool{"2025-04-07": true, "2025-04-08": true, "2025-04-09": true},
+		},
+		{
+			Name:     "Bob",
+			Holidays: map[string]string{"2025-05-26": ""},
+			Away:     map[string]bool{"2025-04-25": true},
+		},
+		{
+			Name:     "Chen",
+			Workdays: []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Thursday},
+			Away:     map[string]bool{},
+		},
+	}
+	if diff := cmp.Diff(expected, members); diff != "" {
+		t.Errorf("ReadRoster() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadRosterErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		line  string
+	}{
+		{name: "Invalid PTO", input: "members:\n  - name: Alice\n    pto: [2025-04-31]\n", line: "line 3"},
+		{name: "Reversed range", input: "members:\n  - name: Alice\n    pto:\n      - 2025-04-09..2025-04-07\n", line: "line 4"},
+		{name: "Invalid workdays", input: "members:\n  - name: Alice\n    workdays: weekdays\n", line: "line 3"},
+		{name: "Member without a name", input: "members:\n  - name: Alice\n  - country: DE\n", line: "line 3"},
+		{name: "Unexpected list item", input: "members:\n  - name: Alice\n    country: DE\n      - 2025-04-07\n", line: "line 4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadRoster(strings.NewReader(tt.input)); err == nil || !strings.Contains(err.Error(), tt.line) {
+				t.Errorf("ReadRoster() error = %v, want an error on %s", err, tt.line)
+			}
+		})
+	}
+}
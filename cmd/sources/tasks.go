@@ -0,0 +1,233 @@
+package sources
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/andre-a-alves/mdcal/cmd/calendar"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Task is a task with a due or scheduled date, read from markdown notes or a task manager
+type Task struct {
+	File      string // path of the file the task was found in; empty when it cannot be linked to
+	Line      int    // line number of the task within the file, starting at 1
+	Text      string // description of the task, without its dates
+	Done      bool
+	Due       time.Time // zero when the task has no due date
+	Scheduled time.Time // zero when the task has no scheduled date
+	Priority  string    // such as A for todo.txt or H for Taskwarrior; empty when the task has none
+	Projects  []string  // +project words, or the Taskwarrior project
+	Contexts  []string  // @context words, or Taskwarrior tags
+}
+
+// TaskFilter selects the tasks shown in the calendar
+type TaskFilter struct {
+	Projects []string // keep tasks in one of these projects or their subprojects, such as work for work.api
+	Contexts []string // keep tasks with one of these contexts
+	ShowDone bool     // keep done tasks, struck through
+}
+
+// matches reports whether the task passes the filter
+func (f TaskFilter) matches(task Task) bool {
+	if task.Done && !f.ShowDone {
+		return false
+	}
+	if len(f.Projects) > 0 && !slices.ContainsFunc(task.Projects, func(project string) bool {
+		return slices.ContainsFunc(f.Projects, func(wanted string) bool {
+			return strings.EqualFold(project, wanted) || strings.HasPrefix(strings.ToLower(project), strings.ToLower(wanted)+".")
+		})
+	}) {
+		return false
+	}
+	if len(f.Contexts) > 0 && !slices.ContainsFunc(task.Contexts, func(context string) bool {
+		return slices.ContainsFunc(f.Contexts, func(wanted string) bool {
+			return strings.EqualFold(context, wanted)
+		})
+	}) {
+		return false
+	}
+	return true
+}
+
+// Date returns the day the task is placed on: its due date, or its scheduled date when it has no due date
+func (t Task) Date() time.Time {
+	if !t.Due.IsZero() {
+		return t.Due
+	}
+	return t.Scheduled
+}
+
+var (
+	// taskPattern matches list items with a checkbox, such as "- [ ] text", "* [x] text" or "1. [ ] text"
+	taskPattern = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+\[(.)\]\s+(.*)$`)
+	// taskDuePattern matches the due dates of the Tasks plugin (📅) and of Dataview inline fields (due::)
+	taskDuePattern = regexp.MustCompile(`(?:📅\s*|\[?\bdue::\s*)(\d{4}-\d{2}-\d{2})\]?`)
+	// taskScheduledPattern matches the scheduled dates of the Tasks plugin (⏳) and of Dataview inline fields
+	taskScheduledPattern = regexp.MustCompile(`(?:⏳\s*|\[?\bscheduled::\s*)(\d{4}-\d{2}-\d{2})\]?`)
+	// taskMetadataPattern matches the other dates of the Tasks plugin: start, created and done
+	taskMetadataPattern = regexp.MustCompile(`(?:[🛫➕✅]\s*|\[?\b(?:start|created|completion)::\s*)\d{4}-\d{2}-\d{2}\]?`)
+)
+
+// parseTaskDate returns the date captured by pattern in text, or the zero time when there is none
+func parseTaskDate(pattern *regexp.Regexp, text string) time.Time {
+	match := pattern.FindStringSubmatch(text)
+	if match == nil {
+		return time.Time{}
+	}
+	date, err := time.Parse(time.DateOnly, match[1])
+	if err != nil {
+		return time.Time{}
+	}
+	return date
+}
+
+// taskProjectsAndContexts returns the +project and @context words of the text of a task, as written in todo.txt
+func taskProjectsAndContexts(text string) ([]string, []string) {
+	var projects, contexts []string
+	for _, word := range strings.Fields(text) {
+		switch {
+		case len(word) > 1 && word[0] == '+':
+			projects = append(projects, word[1:])
+		case len(word) > 1 && word[0] == '@':
+			contexts = append(contexts, word[1:])
+		}
+	}
+	return projects, contexts
+}
+
+// parseTaskLine parses a single markdown line, reporting whether it is a task with a due or scheduled date
+func parseTaskLine(line string) (Task, bool) {
+	match := taskPattern.FindStringSubmatch(line)
+	if match == nil {
+		return Task{}, false
+	}
+
+	task := Task{
+		Done:      match[1] == "x" || match[1] == "X",
+		Due:       parseTaskDate(taskDuePattern, match[2]),
+		Scheduled: parseTaskDate(taskScheduledPattern, match[2]),
+	}
+	if task.Date().IsZero() {
+		return Task{}, false
+	}
+
+	text := taskDuePattern.ReplaceAllString(match[2], "")
+	text = taskScheduledPattern.ReplaceAllString(text, "")
+	text = taskMetadataPattern.ReplaceAllString(text, "")
+	task.Text = strings.Join(strings.Fields(text), " ")
+	task.Projects, task.Contexts = taskProjectsAndContexts(task.Text)
+
+	return task, true
+}
+
+// parseTasks reads the dated tasks of a markdown note, skipping fenced code blocks
+func parseTasks(r io.Reader, file string) ([]Task, error) {
+	var tasks []Task
+	inCode := false
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if trimmed := strings.TrimSpace(text); strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			continue
+		}
+
+		if task, ok := parseTaskLine(text); ok {
+			task.File = file
+			task.Line = line
+			tasks = append(tasks, task)
+		}
+	}
+
+	return tasks, scanner.Err()
+}
+
+// isMarkdown reports whether the file name has a markdown extension
+func isMarkdown(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".md", ".markdown":
+		return true
+	}
+	return false
+}
+
+// ScanTasks walks the markdown notes below dir, skipping hidden directories such as .obsidian and .git,
+// and returns their tasks with a due or scheduled date
+func ScanTasks(dir string) ([]Task, error) {
+	var tasks []Task
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != dir && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !isMarkdown(entry.Name()) {
+			return nil
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		found, err := parseTasks(file, path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		tasks = append(tasks, found...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// TaskAnnotations places the tasks that pass the filter on their days, prefixed with their priority, such as
+// "(A) Call vendor". Tasks read from a file are linked to their line, relative to the directory base.
+func TaskAnnotations(tasks []Task, base string, filter TaskFilter) (map[string][]calendar.Annotation, error) {
+	annotations := make(map[string][]calendar.Annotation)
+	for _, task := range tasks {
+		if !filter.matches(task) {
+			continue
+		}
+
+		annotation := calendar.Annotation{Text: task.Text}
+		if task.Priority != "" {
+			annotation.Text = "(" + task.Priority + ") " + annotation.Text
+		}
+		if task.Done {
+			annotation.Text = "~~" + annotation.Text + "~~"
+		}
+		if task.File != "" {
+			link, err := relativeLink(base, task.File)
+			if err != nil {
+				return nil, err
+			}
+			annotation.Link = fmt.Sprintf("%s#L%d", link, task.Line)
+		}
+
+		date := task.Date().Format(time.DateOnly)
+		annotations[date] = append(annotations[date], annotation)
+	}
+
+	return annotations, nil
+}
@@ -0,0 +1,208 @@
+package sources
+
+import (
+	"github.com/andre-a-alves/mdcal/cmd/calendar"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseTaskLine(t *testing.T) {
+	march := func(day int) time.Time {
+		return time.Date(2025, time.March, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name          string
+		line          string
+		expected      Task
+		expectedFound bool
+	}{
+		{
+			name:          "Tasks plugin due date",
+			line:          "- [ ] Ship release 📅 2025-03-14 ➕ 2025-03-01",
+			expected:      Task{Text: "Ship release", Due: march(14)},
+			expectedFound: true,
+		},
+		{
+			name:          "Done with scheduled date",
+			line:          "  * [x] Write notes ⏳ 2025-03-10 ✅ 2025-03-11",
+			expected:      Task{Text: "Write notes", Done: true, Scheduled: march(10)},
+			expectedFound: true,
+		},
+		{
+			name:          "Dataview inline fields",
+			line:          "1. [ ] Call vendor [due:: 2025-03-20] scheduled:: 2025-03-18",
+			expected:      Task{Text: "Call vendor", Due: march(20), Scheduled: march(18)},
+			expectedFound: true,
+		},
+		{
+			name:          "Projects and contexts",
+			line:          "- [ ] Call vendor +launch @phone 📅 2025-03-14",
+			expected:      Task{Text: "Call vendor +launch @phone", Due: march(14), Projects: []string{"launch"}, Contexts: []string{"phone"}},
+			expectedFound: true,
+		},
+		{
+			name: "Task without a date",
+			line: "- [ ] Someday",
+		},
+		{
+			name: "List item without a checkbox",
+			line: "- Released 📅 2025-03-14",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, found := parseTaskLine(tt.line)
+			if found != tt.expectedFound {
+				t.Fatalf("parseTaskLine(%q) found = %v, want %v", tt.line, found, tt.expectedFound)
+			}
+			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+				t.Errorf("parseTaskLine(%q) mismatch (-want +got):\n%s", tt.line, diff)
+			}
+		})
+	}
+}
+
+func TestParseTasksSkipsCode(t *testing.T) {
+	input := "# Notes\n```\n- [ ] Example 📅 2025-03-01\n```\n- [ ] Real 📅 2025-03-02\n"
+
+	tasks, err := parseTasks(strings.NewReader(input), "notes.md")
+	if err != nil {
+		t.Fatalf("parseTasks() unexpected error: %v", err)
+	}
+
+	expected := []Task{{File: "notes.md", Line: 5, Text: "Real", Due: time.Date(2025, time.March, 2, 0, 0, 0, 0, time.UTC)}}
+	if diff := cmp.Diff(expected, tasks); diff != "" {
+		t.Errorf("parseTasks() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestScanTasks(t *testing.T) {
+	root := t.TempDir()
+	write := func(name string, content string) {
+		t.Helper()
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("work/plan.md", "- [ ] Open 📅 2025-03-14\n- [x] Closed 📅 2025-03-14\n")
+	write(".trash/old.md", "- [ ] Deleted 📅 2025-03-14\n")
+	write("work/data.txt", "- [ ] Not a note 📅 2025-03-14\n")
+
+	tasks, err := ScanTasks(root)
+	if err != nil {
+		t.Fatalf("ScanTasks() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		showDone bool
+		expected map[string][]calendar.Annotation
+	}{
+		{
+			name:     "Open tasks",
+			expected: map[string][]calendar.Annotation{"2025-03-14": {{Text: "Open", Link: "work/plan.md#L1"}}},
+		},
+		{
+			name:     "Done tasks struck through",
+			showDone: true,
+			expected: map[string][]calendar.Annotation{"2025-03-14": {
+				{Text: "Open", Link: "work/plan.md#L1"},
+				{Text: "~~Closed~~", Link: "work/plan.md#L2"},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := TaskAnnotations(tasks, root, TaskFilter{ShowDone: tt.showDone})
+			if err != nil {
+				t.Fatalf("TaskAnnotations() unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+				t.Errorf("TaskAnnotations() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTaskFilterMatches(t *testing.T) {
+	task := Task{Text: "Deploy", Projects: []string{"work.api"}, Contexts: []string{"office"}}
+	done := Task{Text: "Deploy", Done: true}
+
+	tests := []struct {
+		name     string
+		filter   TaskFilter
+		task     Task
+		expected bool
+	}{
+		{name: "No filter", task: task, expected: true},
+		{name: "Parent project", filter: TaskFilter{Projects: []string{"Work"}}, task: task, expected: true},
+		{name: "Other project", filter: TaskFilter{Projects: []string{"home"}}, task: task},
+		{name: "Project prefix is not a parent", filter: TaskFilter{Projects: []string{"wo"}}, task: task},
+		{name: "Context", filter: TaskFilter{Contexts: []string{"phone", "office"}}, task: task, expected: true},
+		{name: "Other context", filter: TaskFilter{Contexts: []string{"phone"}}, task: task},
+		{name: "Done task hidden", task: done},
+		{name: "Done task shown", filter: TaskFilter{ShowDone: true}, task: done, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := tt.filter.matches(tt.task); actual != tt.expected {
+				t.Errorf("matches() = %v, want %v", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTaskAnnotationsMixedSources(t *testing.T) {
+	notes := "- [ ] Draft release notes +launch 📅 2025-03-14\n- [ ] Water plants @home 📅 2025-03-14\n"
+	markdown, err := parseTasks(strings.NewReader(notes), "")
+	if err != nil {
+		t.Fatalf("parseTasks() unexpected error: %v", err)
+	}
+	todoTxt, err := ReadTodoTxt(strings.NewReader("Call vendor +launch.api @phone due:2025-03-14\n"), "")
+	if err != nil {
+		t.Fatalf("ReadTodoTxt() unexpected error: %v", err)
+	}
+	tasks := append(markdown, todoTxt...)
+
+	tests := []struct {
+		name     string
+		filter   TaskFilter
+		expected []calendar.Annotation
+	}{
+		{
+			name:     "Project",
+			filter:   TaskFilter{Projects: []string{"launch"}},
+			expected: []calendar.Annotation{{Text: "Draft release notes +launch"}, {Text: "Call vendor +launch.api @phone"}},
+		},
+		{
+			name:     "Context",
+			filter:   TaskFilter{Contexts: []string{"home", "phone"}},
+			expected: []calendar.Annotation{{Text: "Water plants @home"}, {Text: "Call vendor +launch.api @phone"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := TaskAnnotations(tasks, "", tt.filter)
+			if err != nil {
+				t.Fatalf("TaskAnnotations() unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.expected, actual["2025-03-14"]); diff != "" {
+				t.Errorf("TaskAnnotations() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
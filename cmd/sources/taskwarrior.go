@@ -0,0 +1,78 @@
+package sources
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// taskwarriorDateLayout is the layout of the dates of task export, which are in UTC
+const taskwarriorDateLayout = "20060102T150405Z"
+
+// taskwarriorTask holds the fields of a task export record that place a task in the calendar
+type taskwarriorTask struct {
+	Description string   `json:"description"`
+	Status      string   `json:"status"`
+	Due         string   `json:"due"`
+	Scheduled   string   `json:"scheduled"`
+	Project     string   `json:"project"`
+	Tags        []string `json:"tags"`
+	Priority    string   `json:"priority"`
+}
+
+// parseTaskwarriorDate returns the local day of a task export date, or the zero time when value is empty
+func parseTaskwarriorDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(taskwarriorDateLayout, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	year, month, day := t.Local().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC), nil
+}
+
+// ReadTaskwarrior reads the tasks with a due or scheduled date from the JSON output of task export.
+// Deleted tasks and the templates of recurring tasks are left out, and tags are read as contexts.
+func ReadTaskwarrior(r io.Reader) ([]Task, error) {
+	var records []taskwarriorTask
+	if err := json.NewDecoder(r).Decode(&records); err != nil {
+		return nil, err
+	}
+
+	var tasks []Task
+	for i, record := range records {
+		if record.Status == "deleted" || record.Status == "recurring" {
+			continue
+		}
+
+		due, err := parseTaskwarriorDate(record.Due)
+		if err != nil {
+			return nil, fmt.Errorf("task %d: due: %w", i+1, err)
+		}
+		scheduled, err := parseTaskwarriorDate(record.Scheduled)
+		if err != nil {
+			return nil, fmt.Errorf("task %d: scheduled: %w", i+1, err)
+		}
+
+		task := Task{
+			Text:      record.Description,
+			Done:      record.Status == "completed",
+			Due:       due,
+			Scheduled: scheduled,
+			Priority:  record.Priority,
+			Contexts:  record.Tags,
+		}
+		if task.Date().IsZero() {
+			continue
+		}
+		if record.Project != "" {
+			task.Projects = []string{record.Project}
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
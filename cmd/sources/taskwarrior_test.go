@@ -0,0 +1,77 @@
+package sources
+
+import (
+	"github.com/andre-a-alves/mdcal/cmd/calendar"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReadTaskwarrior(t *testing.T) {
+	// Read the dates in UTC so that their days do not depend on the time zone of the machine
+	local := time.Local
+	time.Local = time.UTC
+	t.Cleanup(func() { time.Local = local })
+
+	input := `[
+{"id":1,"description":"Deploy API","status":"pending","due":"20250314T120000Z","project":"work.api","tags":["office"],"priority":"H"},
+{"id":0,"description":"Send report","status":"completed","due":"20250310T120000Z","project":"work"},
+{"id":2,"description":"Book flights","status":"waiting","scheduled":"20250312T230000Z","project":"home"},
+{"id":0,"description":"Old idea","status":"deleted","due":"20250311T120000Z"},
+{"id":0,"description":"Weekly review","status":"recurring","due":"20250307T120000Z"},
+{"id":3,"description":"Someday","status":"pending"}
+]`
+
+	tasks, err := ReadTaskwarrior(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadTaskwarrior() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		filter   TaskFilter
+		expected map[string][]calendar.Annotation
+	}{
+		{
+			name: "Open tasks",
+			expected: map[string][]calendar.Annotation{
+				"2025-03-14": {{Text: "(H) Deploy API"}},
+				"2025-03-12": {{Text: "Book flights"}},
+			},
+		},
+		{
+			name:   "Project with done tasks",
+			filter: TaskFilter{Projects: []string{"work"}, ShowDone: true},
+			expected: map[string][]calendar.Annotation{
+				"2025-03-14": {{Text: "(H) Deploy API"}},
+				"2025-03-10": {{Text: "~~Send report~~"}},
+			},
+		},
+		{
+			name:     "Tags as contexts",
+			filter:   TaskFilter{Contexts: []string{"office"}},
+			expected: map[string][]calendar.Annotation{"2025-03-14": {{Text: "(H) Deploy API"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := TaskAnnotations(tasks, "", tt.filter)
+			if err != nil {
+				t.Fatalf("TaskAnnotations() unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+				t.Errorf("TaskAnnotations() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReadTaskwarriorInvalidDate(t *testing.T) {
+	_, err := ReadTaskwarrior(strings.NewReader(`[{"description":"Deploy","status":"pending","due":"2025-03-14"}]`))
+	if err == nil || !strings.Contains(err.Error(), "task 1: due") {
+		t.Errorf("ReadTaskwarrior() error = %v, want an error about the due date of task 1", err)
+	}
+}
@@ -0,0 +1,80 @@
+package sources
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	// todoTxtPriorityPattern matches the priority that opens an open todo.txt task, such as "(A) "
+	todoTxtPriorityPattern = regexp.MustCompile(`^\(([A-Z])\)\s+`)
+	// todoTxtDatePattern matches a completion or creation date at the start of a todo.txt task
+	todoTxtDatePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}\s+`)
+)
+
+// parseTodoTxtLine parses a single line of a todo.txt file, reporting whether it is a task with a due date
+// (due:YYYY-MM-DD) or a threshold date (t:YYYY-MM-DD), which is used as its scheduled date
+func parseTodoTxtLine(line string) (Task, bool) {
+	text := strings.TrimSpace(line)
+
+	var task Task
+	if rest, ok := strings.CutPrefix(text, "x "); ok {
+		task.Done = true
+		text = strings.TrimSpace(rest)
+	} else if match := todoTxtPriorityPattern.FindStringSubmatch(text); match != nil {
+		task.Priority = match[1]
+		text = text[len(match[0]):]
+	}
+	// A done task may have both a completion and a creation date
+	for i := 0; i < 2 && todoTxtDatePattern.MatchString(text); i++ {
+		text = todoTxtDatePattern.ReplaceAllString(text, "")
+	}
+
+	var words []string
+	for _, word := range strings.Fields(text) {
+		key, value, _ := strings.Cut(word, ":")
+		switch {
+		case key == "due" || key == "t":
+			date, err := time.Parse(time.DateOnly, value)
+			if err != nil {
+				words = append(words, word)
+			} else if key == "due" {
+				task.Due = date
+			} else {
+				task.Scheduled = date
+			}
+			continue
+		case key == "pri" && task.Done:
+			// Done tasks keep their priority as a pri:A tag
+			task.Priority = value
+			continue
+		}
+		words = append(words, word)
+	}
+	if task.Date().IsZero() {
+		return Task{}, false
+	}
+	task.Text = strings.Join(words, " ")
+	task.Projects, task.Contexts = taskProjectsAndContexts(task.Text)
+
+	return task, true
+}
+
+// ReadTodoTxt reads the tasks with a due or threshold date of a todo.txt file, recording file as their location
+func ReadTodoTxt(r io.Reader, file string) ([]Task, error) {
+	var tasks []Task
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		if task, ok := parseTodoTxtLine(scanner.Text()); ok {
+			task.File = file
+			task.Line = line
+			tasks = append(tasks, task)
+		}
+	}
+
+	return tasks, scanner.Err()
+}
@@ -0,0 +1,91 @@
+package sources
+
+import (
+	"github.com/andre-a-alves/mdcal/cmd/calendar"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseTodoTxtLine(t *testing.T) {
+	march := func(day int) time.Time {
+		return time.Date(2025, time.March, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name          string
+		line          string
+		expected      Task
+		expectedFound bool
+	}{
+		{
+			name: "Priority, creation date, project and context",
+			line: "(A) 2025-03-01 Call vendor +launch @phone due:2025-03-14",
+			expected: Task{
+				Text: "Call vendor +launch @phone", Due: march(14), Priority: "A",
+				Projects: []string{"launch"}, Contexts: []string{"phone"},
+			},
+			expectedFound: true,
+		},
+		{
+			name:          "Done with completion and creation dates",
+			line:          "x 2025-03-12 2025-03-01 Send invoice due:2025-03-10 pri:B",
+			expected:      Task{Text: "Send invoice", Done: true, Due: march(10), Priority: "B"},
+			expectedFound: true,
+		},
+		{
+			name:          "Threshold date",
+			line:          "Renew passport t:2025-03-03",
+			expected:      Task{Text: "Renew passport", Scheduled: march(3)},
+			expectedFound: true,
+		},
+		{
+			name:          "Invalid due date kept in the text",
+			line:          "Fix build due:soon t:2025-03-03",
+			expected:      Task{Text: "Fix build due:soon", Scheduled: march(3)},
+			expectedFound: true,
+		},
+		{
+			name: "Task without a date",
+			line: "(B) Someday +house",
+		},
+		{
+			name: "Blank line",
+			line: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, found := parseTodoTxtLine(tt.line)
+			if found != tt.expectedFound {
+				t.Fatalf("parseTodoTxtLine(%q) found = %v, want %v", tt.line, found, tt.expectedFound)
+			}
+			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+				t.Errorf("parseTodoTxtLine(%q) mismatch (-want +got):\n%s", tt.line, diff)
+			}
+		})
+	}
+}
+
+func TestReadTodoTxt(t *testing.T) {
+	input := "(A) Call vendor +launch @phone due:2025-03-14\n\nWater plants @home due:2025-03-14\n"
+
+	tasks, err := ReadTodoTxt(strings.NewReader(input), "/notes/todo.txt")
+	if err != nil {
+		t.Fatalf("ReadTodoTxt() unexpected error: %v", err)
+	}
+	actual, err := TaskAnnotations(tasks, "/notes", TaskFilter{Contexts: []string{"phone"}})
+	if err != nil {
+		t.Fatalf("TaskAnnotations() unexpected error: %v", err)
+	}
+
+	expected := map[string][]calendar.Annotation{
+		"2025-03-14": {{Text: "(A) Call vendor +launch @phone", Link: "todo.txt#L1"}},
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("ReadTodoTxt() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,50 @@
+package sources
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReadValues reads date,value records from CSV, keyed by date in time.DateOnly format.
+// A first record whose value is not a number is treated as a header, and values of the same date are summed.
+func ReadValues(r io.Reader) (map[string]float64, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	values := make(map[string]float64)
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return values, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		if len(record) < 2 {
+			return nil, fmt.Errorf("line %d: expected date,value", line)
+		}
+
+		value, errValue := strconv.ParseFloat(strings.TrimSpace(record[1]), 64)
+		if errValue != nil && first {
+			// Header
+			continue
+		}
+		date, errDate := time.Parse(time.DateOnly, strings.TrimSpace(record[0]))
+		if errDate != nil {
+			return nil, fmt.Errorf("line %d: invalid date %q: expected YYYY-MM-DD", line, record[0])
+		}
+		if errValue != nil {
+			return nil, fmt.Errorf("line %d: invalid value %q", line, record[1])
+		}
+
+		values[date.Format(time.DateOnly)] += value
+	}
+}
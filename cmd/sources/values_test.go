@@ -0,0 +1,61 @@
+package sources
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReadValues(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expected      map[string]float64
+		expectedError string
+	}{
+		{
+			name:     "Header and duplicates",
+			input:    "date,deploys\n2025-03-03,2\n2025-03-04, 0\n# rollback\n2025-03-03,1.5\n",
+			expected: map[string]float64{"2025-03-03": 3.5, "2025-03-04": 0},
+		},
+		{
+			name:     "Without header",
+			input:    "2025-03-03,7\n",
+			expected: map[string]float64{"2025-03-03": 7},
+		},
+		{
+			name:          "Invalid date",
+			input:         "date,value\n03/03/2025,1\n",
+			expectedError: `line 2: invalid date "03/03/2025": expected YYYY-MM-DD`,
+		},
+		{
+			name:          "Invalid value",
+			input:         "2025-03-03,1\n2025-03-04,many\n",
+			expectedError: `line 2: invalid value "many"`,
+		},
+		{
+			name:          "Missing value",
+			input:         "2025-03-03\n",
+			expectedError: "line 1: expected date,value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := ReadValues(strings.NewReader(tt.input))
+			if tt.expectedError != "" {
+				if err == nil || err.Error() != tt.expectedError {
+					t.Fatalf("ReadValues() error = %v, want %q", err, tt.expectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadValues() unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+				t.Errorf("ReadValues() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package sources
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/andre-a-alves/mdcal/cmd/calendar"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ContactDate is a birthday or anniversary of a contact, repeated every year
+type ContactDate struct {
+	Name        string
+	Anniversary bool // whether the date is an anniversary rather than a birthday
+	Year        int  // zero when the year is not known
+	Month       time.Month
+	Day         int
+}
+
+// vCardDatePattern matches the dates of BDAY and ANNIVERSARY, such as 19850314, 1985-03-14, --0314 or --03-14,
+// optionally followed by a time
+var vCardDatePattern = regexp.MustCompile(`^(\d{4}|--)-?(\d{2})-?(\d{2})(?:T.*)?$`)
+
+// vCardOmitYear is the year Apple Contacts stores for dates without a year, together with X-APPLE-OMIT-YEAR
+const vCardOmitYear = "1604"
+
+// parseVCardDate parses the value of a BDAY or ANNIVERSARY property, reporting whether it is a date
+func parseVCardDate(value string) (ContactDate, bool) {
+	match := vCardDatePattern.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return ContactDate{}, false
+	}
+
+	var date ContactDate
+	if match[1] != "--" && match[1] != vCardOmitYear {
+		date.Year, _ = strconv.Atoi(match[1])
+	}
+	month, _ := strconv.Atoi(match[2])
+	date.Month = time.Month(month)
+	date.Day, _ = strconv.Atoi(match[3])
+
+	// Check the day against a leap year so that February 29 without a year is accepted
+	year := date.Year
+	if year == 0 {
+		year = 2000
+	}
+	if t := time.Date(year, date.Month, date.Day, 0, 0, 0, 0, time.UTC); t.Month() != date.Month || t.Day() != date.Day {
+		return ContactDate{}, false
+	}
+	return date, true
+}
+
+// vCardLine is a logical line of a vCard file
+type vCardLine struct {
+	text string
+	line int // line number in the file where the logical line starts, starting at 1
+}
+
+// readVCardLines reads the logical lines of a vCard file, joining folded lines that continue with a space or tab
+func readVCardLines(r io.Reader) ([]vCardLine, error) {
+	var lines []vCardLine
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1].text += text[1:]
+			continue
+		}
+		lines = append(lines, vCardLine{text: text, line: line})
+	}
+	return lines, scanner.Err()
+}
+
+// ReadVCards reads the birthdays (BDAY) and anniversaries (ANNIVERSARY) of the contacts of a vCard file.
+// Contacts are named by FN, or by N when they have no formatted name. Dates without a month and day, such as the
+// text value "circa 1800" or the reduced precision 1985-03, cannot be placed on the calendar; they are skipped and
+// described in the returned warnings.
+func ReadVCards(r io.Reader) ([]ContactDate, []string, error) {
+	lines, err := readVCardLines(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var dates []ContactDate
+	var warnings []string
+	var card []ContactDate
+	name, fallbackName := "", ""
+	for _, line := range lines {
+		property, value, found := strings.Cut(line.text, ":")
+		if !found {
+			continue
+		}
+		key, _, _ := strings.Cut(strings.ToUpper(property), ";")
+		// Grouped properties such as item1.BDAY are used by some address books
+		if j := strings.LastIndex(key, "."); j >= 0 {
+			key = key[j+1:]
+		}
+
+		switch key {
+		case "BEGIN":
+			card, name, fallbackName = nil, "", ""
+		case "FN":
+			name = strings.TrimSpace(value)
+		case "N":
+			// N lists the family name first: Family;Given;Additional;Prefixes;Suffixes
+			parts := strings.Split(value, ";")
+			if len(parts) > 1 {
+				parts[0], parts[1] = parts[1], parts[0]
+			}
+			fallbackName = strings.Join(strings.Fields(strings.Join(parts, " ")), " ")
+		case "BDAY", "ANNIVERSARY":
+			date, ok := parseVCardDate(value)
+			if !ok {
+				warnings = append(warnings, fmt.Sprintf("line %d: skipped %s %q, which is not a month and day", line.line, key, value))
+				continue
+			}
+			date.Anniversary = key == "ANNIVERSARY"
+			card = append(card, date)
+		case "END":
+			if name == "" {
+				name = fallbackName
+			}
+			for _, date := range card {
+				date.Name = name
+				dates = append(dates, date)
+			}
+			card = nil
+		}
+	}
+
+	return dates, warnings, nil
+}
+
+// isLeapYear reports whether February has 29 days in year
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// occursOn reports whether the date falls on day, placing February 29 on March 1 in other years when marchFirst
+// is set and on February 28 otherwise
+func (d ContactDate) occursOn(day time.Time, marchFirst bool) bool {
+	if d.Year != 0 && day.Year() < d.Year {
+		return false
+	}
+	if d.Month == time.February && d.Day == 29 && !isLeapYear(day.Year()) {
+		if marchFirst {
+			return day.Month() == time.March && day.Day() == 1
+		}
+		return day.Month() == time.February && day.Day() == 28
+	}
+	return day.Month() == d.Month && day.Day() == d.Day
+}
+
+// ContactAnnotator returns an annotator that places the birthdays and anniversaries on their day every year,
+// such as "🎂 Alice (40)" or "💍 Alice & Bob (10 years)" when the year is known. Birthdays on February 29 are
+// placed on March 1 in other years when marchFirst is set, and on February 28 otherwise.
+func ContactAnnotator(dates []ContactDate, marchFirst bool) calendar.Annotator {
+	return func(day time.Time) []calendar.Annotation {
+		var annotations []calendar.Annotation
+		for _, date := range dates {
+			if !date.occursOn(day, marchFirst) {
+				continue
+			}
+
+			text := "🎂 " + date.Name
+			if date.Anniversary {
+				text = "💍 " + date.Name
+			}
+			if years := day.Year() - date.Year; date.Year != 0 && years > 0 {
+				switch {
+				case date.Anniversary && years == 1:
+					text += " (1 year)"
+				case date.Anniversary:
+					text += fmt.Sprintf(" (%d years)", years)
+				default:
+					text += fmt.Sprintf(" (%d)", years)
+				}
+			}
+			annotations = append(annotations, calendar.Annotation{Text: text})
+		}
+		return annotations
+	}
+}
@@ -0,0 +1,152 @@
+package sources
+
+import (
+	"github.com/andre-a-alves/mdcal/cmd/calendar"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseVCardDate(t *testing.T) {
+	tests := []struct {
+		value         string
+		expected      ContactDate
+		expectedFound bool
+	}{
+		{value: "1985-03-14", expected: ContactDate{Year: 1985, Month: time.March, Day: 14}, expectedFound: true},
+		{value: "19850314", expected: ContactDate{Year: 1985, Month: time.March, Day: 14}, expectedFound: true},
+		{value: "1985-03-14T00:00:00Z", expected: ContactDate{Year: 1985, Month: time.March, Day: 14}, expectedFound: true},
+		{value: "--0314", expected: ContactDate{Month: time.March, Day: 14}, expectedFound: true},
+		{value: "--02-29", expected: ContactDate{Month: time.February, Day: 29}, expectedFound: true},
+		{value: "1604-03-14", expected: ContactDate{Month: time.March, Day: 14}, expectedFound: true},
+		{value: "1985-02-30"},
+		{value: "March 14"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			actual, found := parseVCardDate(tt.value)
+			if found != tt.expectedFound {
+				t.Fatalf("parseVCardDate(%q) found = %v, want %v", tt.value, found, tt.expectedFound)
+			}
+			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+				t.Errorf("parseVCardDate(%q) mismatch (-want +got):\n%s", tt.value, diff)
+			}
+		})
+	}
+}
+
+func TestReadVCards(t *testing.T) {
+	input := strings.Join([]string{
+		"BEGIN:VCARD",
+		"VERSION:4.0",
+		"FN:Alice",
+		"  Smith",
+		"BDAY;VALUE=date:19850314",
+		"ANNIVERSARY:2015-06-20",
+		"END:VCARD",
+		"BEGIN:VCARD",
+		"VERSION:3.0",
+		"N:Jones;Bob;;;",
+		"item1.BDAY;X-APPLE-OMIT-YEAR=1604:1604-02-29",
+		"END:VCARD",
+		"BEGIN:VCARD",
+		"FN:Carol",
+		"END:VCARD",
+	}, "\r\n")
+
+	actual, warnings, err := ReadVCards(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadVCards() unexpected error: %v", err)
+	}
+
+	expected := []ContactDate{
+		{Name: "Alice Smith", Year: 1985, Month: time.March, Day: 14},
+		{Name: "Alice Smith", Anniversary: true, Year: 2015, Month: time.June, Day: 20},
+		{Name: "Bob Jones", Month: time.February, Day: 29},
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("ReadVCards() mismatch (-want +got):\n%s", diff)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("ReadVCards() unexpected warnings: %v", warnings)
+	}
+}
+
+func TestReadVCardsUnreadableDates(t *testing.T) {
+	// The folded lines of the notes of Ada shift the physical line numbers of Ben past the logical ones
+	input := "BEGIN:VCARD\nFN:Ada\nNOTE:Met at the\n  conference\n  in Lisbon\nBDAY;VALUE=text:circa 1800\nEND:VCARD\n" +
+		"BEGIN:VCARD\nFN:Ben\nBDAY:1985-03\nANNIVERSARY:2015-06-20\nEND:VCARD\n"
+	actual, warnings, err := ReadVCards(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadVCards() unexpected error: %v", err)
+	}
+
+	expected := []ContactDate{{Name: "Ben", Anniversary: true, Year: 2015, Month: time.June, Day: 20}}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("ReadVCards() mismatch (-want +got):\n%s", diff)
+	}
+	expectedWarnings := []string{
+		`line 6: skipped BDAY "circa 1800", which is not a month and day`,
+		`line 10: skipped BDAY "1985-03", which is not a month and day`,
+	}
+	if diff := cmp.Diff(expectedWarnings, warnings); diff != "" {
+		t.Errorf("ReadVCards() warnings mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestContactAnnotator(t *testing.T) {
+	dates := []ContactDate{
+		{Name: "Alice", Year: 1985, Month: time.March, Day: 14},
+		{Name: "Alice & Dan", Anniversary: true, Year: 2024, Month: time.March, Day: 14},
+		{Name: "Bob", Month: time.February, Day: 29},
+		{Name: "Erin", Year: 2030, Month: time.March, Day: 1},
+	}
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name       string
+		date       time.Time
+		marchFirst bool
+		expected   []calendar.Annotation
+	}{
+		{
+			name:     "Age and years",
+			date:     date(2025, time.March, 14),
+			expected: []calendar.Annotation{{Text: "🎂 Alice (40)"}, {Text: "💍 Alice & Dan (1 year)"}},
+		},
+		{
+			name:     "February 29 on February 28",
+			date:     date(2025, time.February, 28),
+			expected: []calendar.Annotation{{Text: "🎂 Bob"}},
+		},
+		{
+			name:       "February 29 on March 1",
+			date:       date(2025, time.March, 1),
+			marchFirst: true,
+			expected:   []calendar.Annotation{{Text: "🎂 Bob"}},
+		},
+		{
+			name:       "February 29 in a leap year",
+			date:       date(2028, time.March, 1),
+			marchFirst: true,
+		},
+		{
+			name: "Before the year of the date",
+			date: date(2029, time.March, 1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := ContactAnnotator(dates, tt.marchFirst)(tt.date)
+			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+				t.Errorf("ContactAnnotator() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/andre-a-alves/mdcal/cmd/calendar"
+	"github.com/andre-a-alves/mdcal/cmd/sources"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+var tasksCmd = &cobra.Command{
+	Use:   "tasks --scan dir [year] [month] [endMonth|endYear endMonth]",
+	Short: "Generate a calendar of the dated tasks in markdown notes",
+	Long: `tasks walks the markdown notes below a directory and places every open task with a due or scheduled date
+on its day, linked to its file and line. Dates use the Tasks plugin emoji (📅 due, ⏳ scheduled) or Dataview inline
+fields (due:: 2025-03-14, scheduled:: 2025-03-14). Tasks are placed on their due date, or on their scheduled date
+when they have no due date.
+Examples:
+  mdcal tasks --scan ./notes 2025 3                  - Deadlines of March 2025
+  mdcal tasks --scan ./vault --done --layout agenda  - Daily agenda of the current year, done tasks struck through
+
+Tasks from todo.txt or Taskwarrior are added with --todotxt and --taskwarrior, which work with every command.`,
+	Args: cobra.MaximumNArgs(4),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		options, err := initOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		if err := processCommandLineArgs(args, &options); err != nil {
+			return err
+		}
+
+		dir, _ := cmd.Flags().GetString("scan")
+		output, _ := cmd.Flags().GetString("output")
+
+		tasks, err := sources.ScanTasks(sources.ExpandHome(dir))
+		if err != nil {
+			return err
+		}
+		// Links to the notes are relative to the file the calendar is written to
+		annotations, err := sources.TaskAnnotations(tasks, filepath.Dir(output), taskFilterFromFlags(cmd))
+		if err != nil {
+			return err
+		}
+		options.AddAnnotator(0, calendar.Events(annotations))
+
+		return writeCalendar(cmd, options)
+	},
+}
+
+func init() {
+	tasksCmd.Flags().String("scan", "", "Directory of markdown notes to scan for tasks")
+	_ = tasksCmd.MarkFlagRequired("scan")
+
+	rootCmd.AddCommand(tasksCmd)
+}
+
+// taskFilterFromFlags selects the tasks to show from the project, context and done flags
+func taskFilterFromFlags(cmd *cobra.Command) sources.TaskFilter {
+	projects, _ := cmd.Flags().GetStringArray("project")
+	contexts, _ := cmd.Flags().GetStringArray("context")
+	showDone, _ := cmd.Flags().GetBool("done")
+
+	return sources.TaskFilter{Projects: projects, Contexts: contexts, ShowDone: showDone}
+}
+
+// readTaskFile reads the tasks of the file at path with read
+func readTaskFile(path string, read func(r io.Reader) ([]sources.Task, error)) ([]sources.Task, error) {
+	file, err := os.Open(sources.ExpandHome(path))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	tasks, err := read(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return tasks, nil
+}
+
+// addTaskSources adds the tasks of the todo.txt and Taskwarrior files named by the flags to the days of the calendar
+func addTaskSources(cmd *cobra.Command, options *calendar.Options) error {
+	todoTxt, _ := cmd.Flags().GetString("todotxt")
+	taskwarrior, _ := cmd.Flags().GetString("taskwarrior")
+	output, _ := cmd.Flags().GetString("output")
+
+	var tasks []sources.Task
+	if todoTxt != "" {
+		found, err := readTaskFile(todoTxt, func(r io.Reader) ([]sources.Task, error) {
+			return sources.ReadTodoTxt(r, sources.ExpandHome(todoTxt))
+		})
+		if err != nil {
+			return err
+		}
+		tasks = append(tasks, found...)
+	}
+	if taskwarrior != "" {
+		found, err := readTaskFile(taskwarrior, sources.ReadTaskwarrior)
+		if err != nil {
+			return err
+		}
+		tasks = append(tasks, found...)
+	}
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	// Links to the todo.txt file are relative to the file the calendar is written to
+	annotations, err := sources.TaskAnnotations(tasks, filepath.Dir(output), taskFilterFromFlags(cmd))
+	if err != nil {
+		return err
+	}
+	options.AddAnnotator(0, calendar.Events(annotations))
+	return nil
+}
+
+// addOrgSource adds the scheduled and deadline headlines of the Org file named by the flags to the days of the calendar
+func addOrgSource(cmd *cobra.Command, options *calendar.Options) error {
+	org, _ := cmd.Flags().GetString("org")
+	if org == "" {
+		return nil
+	}
+	keywords, _ := cmd.Flags().GetStringArray("org-keyword")
+	tags, _ := cmd.Flags().GetStringArray("org-tag")
+	showDone, _ := cmd.Flags().GetBool("done")
+	output, _ := cmd.Flags().GetString("output")
+
+	file, err := os.Open(sources.ExpandHome(org))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	entries, err := sources.ReadOrg(file, sources.ExpandHome(org))
+	if err != nil {
+		return fmt.Errorf("%s: %w", org, err)
+	}
+	// Links to the headlines are relative to the file the calendar is written to
+	annotator, err := sources.OrgAnnotator(entries, filepath.Dir(output), sources.OrgFilter{Keywords: keywords, Tags: tags, ShowDone: showDone})
+	if err != nil {
+		return err
+	}
+	options.AddAnnotator(0, annotator)
+	return nil
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/andre-a-alves/mdcal/cmd/calendar"
+	"github.com/andre-a-alves/mdcal/cmd/sources"
+	"io"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/spf13/cobra"
+)
+
+var teamCmd = &cobra.Command{
+	Use:   "team --roster team.yaml [year] [month] [endMonth|endYear endMonth]",
+	Short: "Generate a table of who in a team is available each day",
+	Long: `team lists the days of the calendar as rows and the members of a team as columns, showing whether each
+member is in, out on PTO, on a public holiday of their country or not working that day, with the headcount of
+every day and the person-days available in every week. Members, their countries, working days and PTO are read
+from a YAML roster, and --holiday days apply to the whole team.
+Examples:
+  mdcal team --roster team.yaml 2025 3                         - Availability in March 2025
+  mdcal team --roster team.yaml --workweek 2025 4 6            - Weekdays of the second quarter
+  mdcal team --roster team.yaml --holiday 2025-12-24 2025 12   - With a company holiday`,
+	Args: cobra.MaximumNArgs(4),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		options, err := initOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		if err := processCommandLineArgs(args, &options); err != nil {
+			return err
+		}
+
+		// --holiday days are given to every member below rather than annotated
+		options.Annotators = slices.DeleteFunc(options.Annotators, func(entry calendar.AnnotatorEntry) bool {
+			return entry.Priority == holidayPriority
+		})
+
+		rosterPath, _ := cmd.Flags().GetString("roster")
+		holidayValues, _ := cmd.Flags().GetStringArray("holiday")
+
+		members, err := readRoster(rosterPath)
+		if err != nil {
+			return err
+		}
+		holidays, err := parseHolidays(holidayValues)
+		if err != nil {
+			return err
+		}
+		for i := range members {
+			// Members of a country share its holidays, so the team holidays are added to a copy
+			memberHolidays := maps.Clone(members[i].Holidays)
+			if memberHolidays == nil {
+				memberHolidays = make(map[string]string)
+			}
+			maps.Copy(memberHolidays, holidays)
+			members[i].Holidays = memberHolidays
+		}
+
+		return writeOutput(cmd, func(w io.Writer) error {
+			return calendar.WriteTeam(w, options, members)
+		})
+	},
+}
+
+func init() {
+	teamCmd.Flags().String("roster", "", "YAML file of the team members, their countries, working days and PTO")
+	_ = teamCmd.MarkFlagRequired("roster")
+
+	rootCmd.AddCommand(teamCmd)
+}
+
+// readRoster reads the members of the roster file at path
+func readRoster(path string) ([]calendar.Member, error) {
+	file, err := os.Open(sources.ExpandHome(path))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	members, err := sources.ReadRoster(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return members, nil
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// dayLinkData is the data of the --day-link template
+type dayLinkData struct {
+	Date    time.Time
+	Text    string // text the calendar would show, such as the day number
+	Year    int
+	Month   int
+	Day     int
+	Weekday string
+	ISOYear int
+	Week    int
+}
+
+// weekLinkData is the data of the --week-link template
+type weekLinkData struct {
+	Date    time.Time // a day of the week, such as its first displayed day
+	Text    string    // week number the calendar would show
+	ISOYear int
+	Week    int
+}
+
+// sprintNameData is the data of the --sprint-name template
+type sprintNameData struct {
+	N     int       // number of the sprint, starting at 1
+	Start time.Time // first day of the sprint
+	End   time.Time // last day of the sprint
+}
+
+// labelTemplate compiles a --day-link or --week-link template into a label function for calendar.Options.
+// The template is tried on a sample date so that mistakes such as unknown fields are reported up front, and pipes
+// in the result are escaped so that aliases such as [[2025-03-14|14]] do not split the table cell.
+func labelTemplate(flag string, text string, data func(date time.Time, text string) any) (func(date time.Time, text string) string, error) {
+	tmpl, err := template.New(flag).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s template: %w", flag, err)
+	}
+
+	label := func(date time.Time, text string) (string, error) {
+		var sb strings.Builder
+		err := tmpl.Execute(&sb, data(date, text))
+		return strings.ReplaceAll(sb.String(), "|", "\\|"), err
+	}
+	if _, err := label(time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), "1"); err != nil {
+		return nil, fmt.Errorf("invalid %s template: %w", flag, err)
+	}
+
+	return func(date time.Time, text string) string {
+		result, err := label(date, text)
+		if err != nil {
+			return text
+		}
+		return result
+	}, nil
+}
+
+// parseDayLink compiles a --day-link template such as [[{{.Date.Format "2006-01-02"}}]] into a day label function
+func parseDayLink(text string) (func(date time.Time, text string) string, error) {
+	return labelTemplate("day-link", text, func(date time.Time, text string) any {
+		isoYear, week := date.ISOWeek()
+		return dayLinkData{
+			Date:    date,
+			Text:    text,
+			Year:    date.Year(),
+			Month:   int(date.Month()),
+			Day:     date.Day(),
+			Weekday: date.Weekday().String(),
+			ISOYear: isoYear,
+			Week:    week,
+		}
+	})
+}
+
+// parseWeekLink compiles a --week-link template such as [[{{.ISOYear}}-W{{printf "%02d" .Week}}]] into a week label function
+func parseWeekLink(text string) (func(date time.Time, text string) string, error) {
+	return labelTemplate("week-link", text, func(date time.Time, text string) any {
+		isoYear, week := date.ISOWeek()
+		return weekLinkData{Date: date, Text: text, ISOYear: isoYear, Week: week}
+	})
+}
+
+// parseSprintName compiles a --sprint-name template such as "Sprint {{.N}}" into a calendar.Sprints name function.
+// The template is tried on a sample sprint so that mistakes are reported up front.
+func parseSprintName(text string) (func(n int, start time.Time, end time.Time) string, error) {
+	tmpl, err := template.New("sprint-name").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sprint-name template: %w", err)
+	}
+
+	name := func(n int, start time.Time, end time.Time) (string, error) {
+		var sb strings.Builder
+		err := tmpl.Execute(&sb, sprintNameData{N: n, Start: start, End: end})
+		return sb.String(), err
+	}
+	sample := time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC)
+	if _, err := name(1, sample, sample.AddDate(0, 0, 13)); err != nil {
+		return nil, fmt.Errorf("invalid sprint-name template: %w", err)
+	}
+
+	return func(n int, start time.Time, end time.Time) string {
+		result, err := name(n, start, end)
+		if err != nil {
+			return "Sprint " + strconv.Itoa(n)
+		}
+		return result
+	}, nil
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDayLink(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{name: "Obsidian", template: `[[{{.Date.Format "2006-01-02"}}]]`, expected: "[[2025-03-14]]"},
+		{name: "Alias", template: `[[{{.Date.Format "2006-01-02"}}|{{.Text}}]]`, expected: `[[2025-03-14\|14]]`},
+		{name: "URL", template: `[{{.Text}}](https://example.com/{{.Year}}/{{.Month}}/{{.Day}})`, expected: "[14](https://example.com/2025/3/14)"},
+		{name: "Week fields", template: `{{.Weekday}} {{.ISOYear}}-W{{.Week}}`, expected: "Friday 2025-W11"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			label, err := parseDayLink(tt.template)
+			if err != nil {
+				t.Fatalf("parseDayLink() unexpected error: %v", err)
+			}
+			if actual := label(time.Date(2025, time.March, 14, 0, 0, 0, 0, time.UTC), "14"); actual != tt.expected {
+				t.Errorf("parseDayLink() label = %q, want %q", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseWeekLink(t *testing.T) {
+	label, err := parseWeekLink(`[[{{.ISOYear}}-W{{printf "%02d" .Week}}]]`)
+	if err != nil {
+		t.Fatalf("parseWeekLink() unexpected error: %v", err)
+	}
+
+	// December 29, 2025 belongs to the first ISO week of 2026
+	if actual := label(time.Date(2025, time.December, 29, 0, 0, 0, 0, time.UTC), "1"); actual != "[[2026-W01]]" {
+		t.Errorf("parseWeekLink() label = %q, want %q", actual, "[[2026-W01]]")
+	}
+}
+
+func TestParseSprintName(t *testing.T) {
+	name, err := parseSprintName(`{{.Start.Format "2006"}}.{{.N}} (until {{.End.Format "Jan 2"}})`)
+	if err != nil {
+		t.Fatalf("parseSprintName() unexpected error: %v", err)
+	}
+
+	start := time.Date(2025, time.December, 29, 0, 0, 0, 0, time.UTC)
+	if actual := name(26, start, start.AddDate(0, 0, 13)); actual != "2025.26 (until Jan 11)" {
+		t.Errorf("parseSprintName() name = %q, want %q", actual, "2025.26 (until Jan 11)")
+	}
+}
+
+func TestParseLinkErrors(t *testing.T) {
+	for _, template := range []string{"{{.Date", "{{.Nope}}"} {
+		if _, err := parseDayLink(template); err == nil {
+			t.Errorf("parseDayLink(%q) expected an error", template)
+		}
+		if _, err := parseSprintName(template); err == nil {
+			t.Errorf("parseSprintName(%q) expected an error", template)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ParseDays parses a YYYY-MM-DD date or a YYYY-MM-DD..YYYY-MM-DD range into its days in time.DateOnly format
+func ParseDays(value string) ([]string, error) {
+	firstValue, lastValue, isRange := strings.Cut(value, "..")
+	first, err := time.Parse(time.DateOnly, strings.TrimSpace(firstValue))
+	if err != nil {
+		return nil, err
+	}
+	last := first
+	if isRange {
+		if last, err = time.Parse(time.DateOnly, strings.TrimSpace(lastValue)); err != nil {
+			return nil, err
+		}
+		if last.Before(first) {
+			return nil, errors.New("range ends before it starts")
+		}
+	}
+
+	var days []string
+	for cur := first; !cur.After(last); cur = cur.AddDate(0, 0, 1) {
+		days = append(days, cur.Format(time.DateOnly))
+	}
+	return days, nil
+}
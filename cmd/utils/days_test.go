@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseDays(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected []string
+		wantErr  bool
+	}{
+		{name: "Single day", value: "2025-03-14", expected: []string{"2025-03-14"}},
+		{name: "Range across months", value: "2025-02-27 .. 2025-03-01", expected: []string{"2025-02-27", "2025-02-28", "2025-03-01"}},
+		{name: "Reversed range", value: "2025-03-14..2025-03-10", wantErr: true},
+		{name: "Not a date", value: "2025-3-14", wantErr: true},
+		{name: "Range end not a date", value: "2025-03-14..friday", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			days, err := ParseDays(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDays() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.expected, days); diff != "" {
+				t.Errorf("ParseDays() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -2,12 +2,22 @@ package utils
 
 import (
 	"strings"
+
+	"github.com/mattn/go-runewidth"
 )
 
-// PadRight pads a string with spaces to the specified width
+// displayWidth measures cells independently of the locale, counting ambiguous characters such as ░ as one column
+var displayWidth = &runewidth.Condition{EastAsianWidth: false}
+
+// DisplayWidth returns the number of terminal columns s occupies, counting wide characters such as emoji as two
+func DisplayWidth(s string) int {
+	return displayWidth.StringWidth(s)
+}
+
+// PadRight pads a string with spaces to the specified display width
 func PadRight(s string, width int) string {
-	if len(s) < width {
-		return s + strings.Repeat(" ", width-len(s))
+	if w := DisplayWidth(s); w < width {
+		return s + strings.Repeat(" ", width-w)
 	}
 	return s
 }
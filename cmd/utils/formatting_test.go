@@ -37,6 +37,18 @@ func TestPadRight(t *testing.T) {
 			width:    5,
 			expected: "abcde",
 		},
+		{
+			name:     "Ambiguous width glyph",
+			input:    "3 ▓",
+			width:    5,
+			expected: "3 ▓  ",
+		},
+		{
+			name:     "Wide emoji",
+			input:    "🟩",
+			width:    3,
+			expected: "🟩 ",
+		},
 		{
 			name:     "String longer than width",
 			input:    "abcdefg",
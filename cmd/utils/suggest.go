@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+)
+
+// levenshtein computes the edit distance between two strings
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(rb)]
+}
+
+// Suggest returns the candidate closest to the input, or an empty string if none is close enough
+// to be a plausible typo. Comparison is case-insensitive and ties are broken alphabetically.
+func Suggest(input string, candidates []string) string {
+	input = strings.ToLower(input)
+	if input == "" {
+		return ""
+	}
+
+	sorted := append([]string(nil), candidates...)
+	sort.Strings(sorted)
+
+	best, bestDistance := "", -1
+	for _, candidate := range sorted {
+		d := levenshtein(input, strings.ToLower(candidate))
+		if bestDistance < 0 || d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+
+	// Only suggest candidates within a third of the input length, allowing at least two edits
+	if bestDistance < 0 || bestDistance > max(2, len([]rune(input))/3) {
+		return ""
+	}
+
+	return best
+}
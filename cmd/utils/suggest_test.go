@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"monday", "monday", 0},
+		{"mnoday", "monday", 2},
+		{"centre", "center", 2},
+		{"kitten", "sitting", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if actual := levenshtein(tt.a, tt.b); actual != tt.expected {
+				t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		candidates []string
+		expected   string
+	}{
+		{
+			name:       "Transposed letters",
+			input:      "mnoday",
+			candidates: []string{"monday", "tuesday", "sunday"},
+			expected:   "monday",
+		},
+		{
+			name:       "Case insensitive",
+			input:      "CENTRE",
+			candidates: []string{"left", "center", "right"},
+			expected:   "center",
+		},
+		{
+			name:       "Too far from any candidate",
+			input:      "diagonal",
+			candidates: []string{"left", "center", "right"},
+			expected:   "",
+		},
+		{
+			name:       "Empty input",
+			input:      "",
+			candidates: []string{"left"},
+			expected:   "",
+		},
+		{
+			name:       "No candidates",
+			input:      "left",
+			candidates: nil,
+			expected:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := Suggest(tt.input, tt.candidates)
+			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+				t.Errorf("Suggest(%q) mismatch (-want +got):\n%s", tt.input, diff)
+			}
+		})
+	}
+}
@@ -5,25 +5,39 @@ import (
 	"time"
 )
 
-// ParseWeekday converts a string representation of a weekday to time.Weekday
-func ParseWeekday(day string) time.Weekday {
-	day = strings.ToLower(day)
-	switch {
-	case day == "sunday" || day == "sun":
-		return time.Sunday
-	case day == "monday" || day == "mon":
-		return time.Monday
-	case day == "tuesday" || day == "tue" || day == "tues":
-		return time.Tuesday
-	case day == "wednesday" || day == "wed":
-		return time.Wednesday
-	case day == "thursday" || day == "thu" || day == "thur" || day == "thurs":
-		return time.Thursday
-	case day == "friday" || day == "fri":
-		return time.Friday
-	case day == "saturday" || day == "sat":
-		return time.Saturday
-	default:
-		return time.Monday // Default to Monday
+// weekdaySpellings lists every accepted spelling of each weekday, in lower case
+var weekdaySpellings = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"sun":       time.Sunday,
+	"monday":    time.Monday,
+	"mon":       time.Monday,
+	"tuesday":   time.Tuesday,
+	"tue":       time.Tuesday,
+	"tues":      time.Tuesday,
+	"wednesday": time.Wednesday,
+	"wed":       time.Wednesday,
+	"thursday":  time.Thursday,
+	"thu":       time.Thursday,
+	"thur":      time.Thursday,
+	"thurs":     time.Thursday,
+	"friday":    time.Friday,
+	"fri":       time.Friday,
+	"saturday":  time.Saturday,
+	"sat":       time.Saturday,
+}
+
+// LookupWeekday converts a string representation of a weekday to time.Weekday,
+// reporting whether the string is a recognised weekday name
+func LookupWeekday(day string) (time.Weekday, bool) {
+	weekday, ok := weekdaySpellings[strings.ToLower(day)]
+	return weekday, ok
+}
+
+// WeekdaySpellings returns every accepted spelling of a weekday, in lower case
+func WeekdaySpellings() []string {
+	spellings := make([]string, 0, len(weekdaySpellings))
+	for spelling := range weekdaySpellings {
+		spellings = append(spellings, spelling)
 	}
+	return spellings
 }
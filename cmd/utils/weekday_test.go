@@ -7,7 +7,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 )
 
-func TestParseWeekday(t *testing.T) {
+func TestLookupWeekdaySpellings(t *testing.T) {
 	tests := []struct {
 		name     string
 		input    string
@@ -118,30 +118,40 @@ func TestParseWeekday(t *testing.T) {
 			input:    "WEDNESDAY",
 			expected: time.Wednesday,
 		},
-
-		// Edge cases
-		{
-			name:     "Empty string",
-			input:    "",
-			expected: time.Monday, // Default
-		},
-		{
-			name:     "Invalid day",
-			input:    "NotADay",
-			expected: time.Monday, // Default
-		},
-		{
-			name:     "Partial match",
-			input:    "Mond",
-			expected: time.Monday, // Default
-		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			actual := ParseWeekday(tt.input)
+			actual, found := LookupWeekday(tt.input)
+			if !found {
+				t.Fatalf("LookupWeekday(%q) found no weekday", tt.input)
+			}
 			if diff := cmp.Diff(tt.expected, actual); diff != "" {
-				t.Errorf("ParseWeekday(%q) mismatch (-want +got):\n%s", tt.input, diff)
+				t.Errorf("LookupWeekday(%q) mismatch (-want +got):\n%s", tt.input, diff)
+			}
+		})
+	}
+}
+
+func TestLookupWeekday(t *testing.T) {
+	tests := []struct {
+		input         string
+		expected      time.Weekday
+		expectedFound bool
+	}{
+		{input: "Thurs", expected: time.Thursday, expectedFound: true},
+		{input: "sun", expected: time.Sunday, expectedFound: true},
+		{input: "mnoday", expected: time.Sunday, expectedFound: false},
+		{input: "", expected: time.Sunday, expectedFound: false},
+		{input: "NotADay", expected: time.Sunday, expectedFound: false},
+		{input: "Mond", expected: time.Sunday, expectedFound: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			actual, found := LookupWeekday(tt.input)
+			if actual != tt.expected || found != tt.expectedFound {
+				t.Errorf("LookupWeekday(%q) = (%v, %v), want (%v, %v)", tt.input, actual, found, tt.expected, tt.expectedFound)
 			}
 		})
 	}